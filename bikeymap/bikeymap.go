@@ -1,8 +1,12 @@
 package bikeymap
 
 import (
-	"errors"
 	"fmt"
+	"iter"
+	"maps"
+
+	"github.com/aeimer/go-multikeymap/internal/bloom"
+	"github.com/aeimer/go-multikeymap/internal/keyorder"
 )
 
 // BiKeyMap is a generic in-memory map with two independent keys for each value.
@@ -11,42 +15,173 @@ type BiKeyMap[KeyA comparable, KeyB comparable, V any] struct {
 	dataByKeyA map[KeyA]V
 	keyAByKeyB map[KeyB]KeyA
 	keyBByKeyA map[KeyA]KeyB
+	// insertionOrder is the order of the first keys for iteration if WithInsertionOrder is used, nil otherwise.
+	insertionOrder *keyorder.List[KeyA]
+	// filterA and filterB are the bloom filters of the keys if WithBloomFilter is used
+	// and the key type is supported, nil otherwise.
+	filterA *bloom.Filter[KeyA]
+	filterB *bloom.Filter[KeyB]
 }
 
 // New creates a new instance of BiKeyMap.
-func New[KeyA comparable, KeyB comparable, V any]() *BiKeyMap[KeyA, KeyB, V] {
-	return &BiKeyMap[KeyA, KeyB, V]{
+func New[KeyA comparable, KeyB comparable, V any](opts ...Option) *BiKeyMap[KeyA, KeyB, V] {
+	m := &BiKeyMap[KeyA, KeyB, V]{
 		dataByKeyA: make(map[KeyA]V),
 		keyAByKeyB: make(map[KeyB]KeyA),
 		keyBByKeyA: make(map[KeyA]KeyB),
 	}
+	o := newOptions(opts)
+	if o.insertionOrder {
+		m.insertionOrder = keyorder.New[KeyA]()
+	}
+	if o.bloomFilter {
+		m.filterA = newFilter[KeyA]()
+		m.filterB = newFilter[KeyB]()
+	}
+	return m
 }
 
 // Put stores a value with two keys. It only fails if one of the keys is already set without the other.
+// The returned error is a *ConflictError.
 func (m *BiKeyMap[KeyA, KeyB, V]) Put(keyA KeyA, keyB KeyB, value V) error {
 	// Check if one key is set without the other, or if they do not point to each other.
 	if existingKeyA, keyBExists := m.keyAByKeyB[keyB]; keyBExists && existingKeyA != keyA {
-		return errors.New("keyB is already set with a different keyA")
+		return newKeyBConflictError(keyA, keyB, existingKeyA)
 	}
 	if existingKeyB, keyAExists := m.keyBByKeyA[keyA]; keyAExists && existingKeyB != keyB {
-		return errors.New("keyA is already set with a different keyB")
+		return newKeyAConflictError(keyA, keyB, existingKeyB)
 	}
 
 	// Put the new values for both keys.
+	m.set(keyA, keyB, value)
+	return nil
+}
+
+// set stores an entry whose keys are either both new or already paired with each other.
+func (m *BiKeyMap[KeyA, KeyB, V]) set(keyA KeyA, keyB KeyB, value V) {
+	_, exists := m.keyBByKeyA[keyA]
 	m.dataByKeyA[keyA] = value
 	m.keyAByKeyB[keyB] = keyA
 	m.keyBByKeyA[keyA] = keyB
+	if exists {
+		return
+	}
+	if m.insertionOrder != nil {
+		m.insertionOrder.PushBack(keyA)
+	}
+	filterAdd(m.filterA, keyA, m.keyBByKeyA)
+	filterAdd(m.filterB, keyB, m.keyAByKeyB)
+}
+
+// ForcePut stores a value with two keys like Put, but never fails.
+// Entries whose keyA or keyB is paired with a different key are removed first and returned.
+func (m *BiKeyMap[KeyA, KeyB, V]) ForcePut(keyA KeyA, keyB KeyB, value V) []Entry[KeyA, KeyB, V] {
+	var evicted []Entry[KeyA, KeyB, V]
+	if existingKeyB, keyAExists := m.keyBByKeyA[keyA]; keyAExists && existingKeyB != keyB {
+		evicted = append(evicted, Entry[KeyA, KeyB, V]{KeyA: keyA, KeyB: existingKeyB, Value: m.dataByKeyA[keyA]})
+		_ = m.RemoveByKeyA(keyA)
+	}
+	if existingKeyA, keyBExists := m.keyAByKeyB[keyB]; keyBExists && existingKeyA != keyA {
+		evicted = append(evicted, Entry[KeyA, KeyB, V]{KeyA: existingKeyA, KeyB: keyB, Value: m.dataByKeyA[existingKeyA]})
+		_ = m.RemoveByKeyB(keyB)
+	}
+
+	m.set(keyA, keyB, value)
+	return evicted
+}
+
+// PutIfAbsent stores a value with two keys only if neither of the keys is set.
+// It returns true if the value was stored.
+func (m *BiKeyMap[KeyA, KeyB, V]) PutIfAbsent(keyA KeyA, keyB KeyB, value V) bool {
+	if _, keyAExists := m.keyBByKeyA[keyA]; keyAExists {
+		return false
+	}
+	if _, keyBExists := m.keyAByKeyB[keyB]; keyBExists {
+		return false
+	}
+
+	m.set(keyA, keyB, value)
+	return true
+}
+
+// Replace replaces the value of an existing entry only if keyA is paired with keyB.
+// It returns true if the value was replaced.
+func (m *BiKeyMap[KeyA, KeyB, V]) Replace(keyA KeyA, keyB KeyB, value V) bool {
+	if existingKeyB, keyAExists := m.keyBByKeyA[keyA]; !keyAExists || existingKeyB != keyB {
+		return false
+	}
+
+	m.dataByKeyA[keyA] = value
+	return true
+}
+
+// RekeyA moves the entry of oldKeyA to newKeyA, keeping its keyB, value and position in the insertion order.
+// It returns ErrKeyANotFound if oldKeyA does not exist
+// and a *ConflictError matching ErrKeyAConflict if newKeyA is already set.
+func (m *BiKeyMap[KeyA, KeyB, V]) RekeyA(oldKeyA KeyA, newKeyA KeyA) error {
+	keyB, exists := m.keyBByKeyA[oldKeyA]
+	if !exists {
+		return ErrKeyANotFound
+	}
+	if oldKeyA == newKeyA {
+		return nil
+	}
+	if existingKeyB, newKeyAExists := m.keyBByKeyA[newKeyA]; newKeyAExists {
+		return newKeyAConflictError(newKeyA, keyB, existingKeyB)
+	}
+
+	m.dataByKeyA[newKeyA] = m.dataByKeyA[oldKeyA]
+	m.keyBByKeyA[newKeyA] = keyB
+	m.keyAByKeyB[keyB] = newKeyA
+	delete(m.dataByKeyA, oldKeyA)
+	delete(m.keyBByKeyA, oldKeyA)
+	if m.insertionOrder != nil {
+		m.insertionOrder.Rekey(oldKeyA, newKeyA)
+	}
+	filterAdd(m.filterA, newKeyA, m.keyBByKeyA)
+	filterRemove(m.filterA, m.keyBByKeyA)
+	return nil
+}
+
+// RekeyB moves the entry of oldKeyB to newKeyB, keeping its keyA and value.
+// It returns ErrKeyBNotFound if oldKeyB does not exist
+// and a *ConflictError matching ErrKeyBConflict if newKeyB is already set.
+func (m *BiKeyMap[KeyA, KeyB, V]) RekeyB(oldKeyB KeyB, newKeyB KeyB) error {
+	keyA, exists := m.keyAByKeyB[oldKeyB]
+	if !exists {
+		return ErrKeyBNotFound
+	}
+	if oldKeyB == newKeyB {
+		return nil
+	}
+	if existingKeyA, newKeyBExists := m.keyAByKeyB[newKeyB]; newKeyBExists {
+		return newKeyBConflictError(keyA, newKeyB, existingKeyA)
+	}
+
+	m.keyAByKeyB[newKeyB] = keyA
+	m.keyBByKeyA[keyA] = newKeyB
+	delete(m.keyAByKeyB, oldKeyB)
+	filterAdd(m.filterB, newKeyB, m.keyAByKeyB)
+	filterRemove(m.filterB, m.keyAByKeyB)
 	return nil
 }
 
 // GetByKeyA retrieves a value using the first key.
 func (m *BiKeyMap[KeyA, KeyB, V]) GetByKeyA(keyA KeyA) (V, bool) {
+	if !mayContain(m.filterA, keyA) {
+		var zero V
+		return zero, false
+	}
 	value, exists := m.dataByKeyA[keyA]
 	return value, exists
 }
 
 // GetByKeyB retrieves a value using the second key.
 func (m *BiKeyMap[KeyA, KeyB, V]) GetByKeyB(keyB KeyB) (V, bool) {
+	if !mayContain(m.filterB, keyB) {
+		var zero V
+		return zero, false
+	}
 	keyA, exists := m.keyAByKeyB[keyB]
 	if !exists {
 		var zero V
@@ -57,36 +192,172 @@ func (m *BiKeyMap[KeyA, KeyB, V]) GetByKeyB(keyB KeyB) (V, bool) {
 	return value, exists
 }
 
+// KeyBForKeyA returns the second key paired with the first key.
+func (m *BiKeyMap[KeyA, KeyB, V]) KeyBForKeyA(keyA KeyA) (KeyB, bool) {
+	if !mayContain(m.filterA, keyA) {
+		var zero KeyB
+		return zero, false
+	}
+	keyB, exists := m.keyBByKeyA[keyA]
+	return keyB, exists
+}
+
+// KeyAForKeyB returns the first key paired with the second key.
+func (m *BiKeyMap[KeyA, KeyB, V]) KeyAForKeyB(keyB KeyB) (KeyA, bool) {
+	if !mayContain(m.filterB, keyB) {
+		var zero KeyA
+		return zero, false
+	}
+	keyA, exists := m.keyAByKeyB[keyB]
+	return keyA, exists
+}
+
+// HasKeyA checks if the first key exists.
+func (m *BiKeyMap[KeyA, KeyB, V]) HasKeyA(keyA KeyA) bool {
+	if !mayContain(m.filterA, keyA) {
+		return false
+	}
+	_, exists := m.keyBByKeyA[keyA]
+	return exists
+}
+
+// HasKeyB checks if the second key exists.
+func (m *BiKeyMap[KeyA, KeyB, V]) HasKeyB(keyB KeyB) bool {
+	if !mayContain(m.filterB, keyB) {
+		return false
+	}
+	_, exists := m.keyAByKeyB[keyB]
+	return exists
+}
+
+// KeysA returns a slice of all first keys in the map.
+func (m *BiKeyMap[KeyA, KeyB, V]) KeysA() []KeyA {
+	keys := make([]KeyA, 0, len(m.keyBByKeyA))
+	for keyA := range m.All() {
+		keys = append(keys, keyA)
+	}
+	return keys
+}
+
+// KeysB returns a slice of all second keys in the map.
+func (m *BiKeyMap[KeyA, KeyB, V]) KeysB() []KeyB {
+	keys := make([]KeyB, 0, len(m.keyAByKeyB))
+	if m.insertionOrder != nil {
+		for keyA := range m.insertionOrder.All() {
+			keys = append(keys, m.keyBByKeyA[keyA])
+		}
+		return keys
+	}
+	for keyB := range m.keyAByKeyB {
+		keys = append(keys, keyB)
+	}
+	return keys
+}
+
+// MoveToFront moves an entry to the front of the insertion order.
+// It returns ErrNoInsertionOrder if the map was not created with WithInsertionOrder
+// and ErrKeyANotFound if keyA does not exist.
+func (m *BiKeyMap[KeyA, KeyB, V]) MoveToFront(keyA KeyA) error {
+	if m.insertionOrder == nil {
+		return ErrNoInsertionOrder
+	}
+	if !m.insertionOrder.MoveToFront(keyA) {
+		return ErrKeyANotFound
+	}
+	return nil
+}
+
+// MoveToBack moves an entry to the back of the insertion order.
+// It returns ErrNoInsertionOrder if the map was not created with WithInsertionOrder
+// and ErrKeyANotFound if keyA does not exist.
+func (m *BiKeyMap[KeyA, KeyB, V]) MoveToBack(keyA KeyA) error {
+	if m.insertionOrder == nil {
+		return ErrNoInsertionOrder
+	}
+	if !m.insertionOrder.MoveToBack(keyA) {
+		return ErrKeyANotFound
+	}
+	return nil
+}
+
+// Inverse returns a view of the map with the roles of KeyA and KeyB swapped.
+func (m *BiKeyMap[KeyA, KeyB, V]) Inverse() *Inverse[KeyB, KeyA, V] {
+	return &Inverse[KeyB, KeyA, V]{m: m}
+}
+
 // RemoveByKeyA removes a value using the first key, ensuring the corresponding second key is also deleted.
+// It returns ErrKeyANotFound if keyA does not exist.
 func (m *BiKeyMap[KeyA, KeyB, V]) RemoveByKeyA(keyA KeyA) error {
 	// Verify that keyA exists and retrieve the associated keyB.
 	keyB, ok := m.keyBByKeyA[keyA]
 	if !ok {
-		return errors.New("keyA does not exist")
+		return ErrKeyANotFound
 	}
 
 	// Remove keyA, keyB, and the associated value.
-	delete(m.dataByKeyA, keyA)
-	delete(m.keyAByKeyB, keyB)
-	delete(m.keyBByKeyA, keyA)
-
+	m.remove(keyA, keyB)
 	return nil
 }
 
 // RemoveByKeyB removes a value using the second key, ensuring the corresponding first key is also deleted.
+// It returns ErrKeyBNotFound if keyB does not exist.
 func (m *BiKeyMap[KeyA, KeyB, V]) RemoveByKeyB(keyB KeyB) error {
 	// Verify that keyB exists and retrieve the associated keyA.
 	keyA, ok := m.keyAByKeyB[keyB]
 	if !ok {
-		return errors.New("keyB does not exist")
+		return ErrKeyBNotFound
 	}
 
 	// Remove keyA, keyB, and the associated value.
+	m.remove(keyA, keyB)
+	return nil
+}
+
+// remove deletes the entry of keyA and keyB.
+func (m *BiKeyMap[KeyA, KeyB, V]) remove(keyA KeyA, keyB KeyB) {
 	delete(m.dataByKeyA, keyA)
 	delete(m.keyAByKeyB, keyB)
 	delete(m.keyBByKeyA, keyA)
+	if m.insertionOrder != nil {
+		m.insertionOrder.Remove(keyA)
+	}
+	filterRemove(m.filterA, m.keyBByKeyA)
+	filterRemove(m.filterB, m.keyAByKeyB)
+}
 
-	return nil
+// Keys returns a slice of all first keys in the map. It is the same as KeysA.
+func (m *BiKeyMap[KeyA, KeyB, V]) Keys() []KeyA {
+	return m.KeysA()
+}
+
+// Has checks if the first key exists. It is the same as HasKeyA.
+func (m *BiKeyMap[KeyA, KeyB, V]) Has(keyA KeyA) bool {
+	return m.HasKeyA(keyA)
+}
+
+// Get retrieves a value using the first key. It is the same as GetByKeyA.
+func (m *BiKeyMap[KeyA, KeyB, V]) Get(keyA KeyA) (V, bool) {
+	return m.GetByKeyA(keyA)
+}
+
+// All returns an iterator over all first keys and their values.
+// With WithInsertionOrder, the entries are returned in insertion order.
+func (m *BiKeyMap[KeyA, KeyB, V]) All() iter.Seq2[KeyA, V] {
+	return func(yield func(KeyA, V) bool) {
+		if m.insertionOrder != nil {
+			for keyA := range m.insertionOrder.All() {
+				if !yield(keyA, m.dataByKeyA[keyA]) {
+					return
+				}
+			}
+			return
+		}
+		for keyA, value := range m.dataByKeyA {
+			if !yield(keyA, value) {
+				return
+			}
+		}
+	}
 }
 
 // Empty checks if the map is empty.
@@ -102,7 +373,7 @@ func (m *BiKeyMap[KeyA, KeyB, V]) Size() int {
 // Values returns a slice of all values in the map.
 func (m *BiKeyMap[KeyA, KeyB, V]) Values() []V {
 	values := make([]V, 0, len(m.dataByKeyA))
-	for _, value := range m.dataByKeyA {
+	for _, value := range m.All() {
 		values = append(values, value)
 	}
 	return values
@@ -113,9 +384,26 @@ func (m *BiKeyMap[KeyA, KeyB, V]) Clear() {
 	m.dataByKeyA = make(map[KeyA]V)
 	m.keyAByKeyB = make(map[KeyB]KeyA)
 	m.keyBByKeyA = make(map[KeyA]KeyB)
+	if m.insertionOrder != nil {
+		m.insertionOrder.Clear()
+	}
+	if m.filterA != nil {
+		m.filterA.Rebuild(maps.Keys(m.keyBByKeyA), 0)
+	}
+	if m.filterB != nil {
+		m.filterB.Rebuild(maps.Keys(m.keyAByKeyB), 0)
+	}
 }
 
 // String returns a string representation of the map.
+// With WithInsertionOrder, the entries are listed in insertion order.
 func (m *BiKeyMap[KeyA, KeyB, V]) String() string {
+	if m.insertionOrder != nil {
+		entries := make([]Entry[KeyA, KeyB, V], 0, len(m.dataByKeyA))
+		for keyA, value := range m.All() {
+			entries = append(entries, Entry[KeyA, KeyB, V]{KeyA: keyA, KeyB: m.keyBByKeyA[keyA], Value: value})
+		}
+		return fmt.Sprintf("BiKeyMap: %v", entries)
+	}
 	return fmt.Sprintf("BiKeyMap: %v", m.dataByKeyA)
 }
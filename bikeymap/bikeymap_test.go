@@ -22,6 +22,19 @@ func ExampleNew() {
 	// [Key B] value: value1, exists: true
 }
 
+func ExampleWithInsertionOrder() {
+	bm := New[string, int, string](WithInsertionOrder())
+	_ = bm.Put("c", 3, "value3")
+	_ = bm.Put("a", 1, "value1")
+	_ = bm.Put("b", 2, "value2")
+	_ = bm.MoveToFront("b")
+	fmt.Println(bm.KeysA())
+	fmt.Println(bm.KeysB())
+	// Output:
+	// [b c a]
+	// [2 3 1]
+}
+
 func TestBiKeyMap_ImplementsContainerInterface(t *testing.T) {
 	instance := New[int, int, int]()
 	if _, ok := any(instance).(container.Container[int]); !ok {
@@ -29,6 +42,21 @@ func TestBiKeyMap_ImplementsContainerInterface(t *testing.T) {
 	}
 }
 
+func TestBiKeyMap_KeyedContainer(t *testing.T) {
+	bm := New[string, int, string]()
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+	require.NoError(t, bm.Put("keyA2", 2, "value2"))
+
+	var keyed container.KeyedContainer[string, string] = bm
+	assert.ElementsMatch(t, []string{"keyA1", "keyA2"}, keyed.Keys())
+	assert.True(t, keyed.Has("keyA1"))
+	assert.False(t, keyed.Has("keyA3"))
+	value, exists := keyed.Get("keyA2")
+	assert.True(t, exists)
+	assert.Equal(t, "value2", value)
+	assert.Equal(t, map[string]string{"keyA1": "value1", "keyA2": "value2"}, container.Collect(keyed))
+}
+
 func TestBiKeyMap_SetAndGet(t *testing.T) {
 	bm := New[string, int, string]()
 
@@ -53,10 +81,153 @@ func TestBiKeyMap_SetDuplicateKeys(t *testing.T) {
 	require.NoError(t, err)
 
 	err = bm.Put("keyA2", 1, "value2")
-	require.Error(t, err)
+	require.ErrorIs(t, err, ErrKeyBConflict)
+	require.ErrorIs(t, err, ErrConflict)
+
+	var conflictErr *ConflictError[string, int]
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, "keyA2", conflictErr.KeyA)
+	assert.Equal(t, "keyA1", conflictErr.ExistingKeyA)
 
 	err = bm.Put("keyA1", 2, "value2")
-	require.Error(t, err)
+	require.ErrorIs(t, err, ErrKeyAConflict)
+	require.NotErrorIs(t, err, ErrKeyBConflict)
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, 2, conflictErr.KeyB)
+	assert.Equal(t, 1, conflictErr.ExistingKeyB)
+}
+
+func TestBiKeyMap_ForcePut(t *testing.T) {
+	bm := New[string, int, string]()
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+	require.NoError(t, bm.Put("keyA2", 2, "value2"))
+	require.NoError(t, bm.Put("keyA3", 3, "value3"))
+
+	// Updating an existing pair evicts nothing.
+	evicted := bm.ForcePut("keyA3", 3, "value30")
+	assert.Empty(t, evicted)
+
+	// Pairing keyA1 with keyB 2 evicts both existing pairs.
+	evicted = bm.ForcePut("keyA1", 2, "value12")
+	assert.ElementsMatch(t, []Entry[string, int, string]{
+		{KeyA: "keyA1", KeyB: 1, Value: "value1"},
+		{KeyA: "keyA2", KeyB: 2, Value: "value2"},
+	}, evicted)
+
+	value, exists := bm.GetByKeyB(2)
+	assert.True(t, exists)
+	assert.Equal(t, "value12", value)
+	_, exists = bm.GetByKeyB(1)
+	assert.False(t, exists)
+	_, exists = bm.GetByKeyA("keyA2")
+	assert.False(t, exists)
+	assert.Equal(t, 2, bm.Size())
+}
+
+func TestBiKeyMap_PutIfAbsent(t *testing.T) {
+	bm := New[string, int, string]()
+	assert.True(t, bm.PutIfAbsent("keyA1", 1, "value1"))
+	assert.False(t, bm.PutIfAbsent("keyA1", 1, "value2"))
+	assert.False(t, bm.PutIfAbsent("keyA1", 2, "value2"))
+	assert.False(t, bm.PutIfAbsent("keyA2", 1, "value2"))
+
+	value, _ := bm.GetByKeyA("keyA1")
+	assert.Equal(t, "value1", value)
+	assert.Equal(t, 1, bm.Size())
+}
+
+func TestBiKeyMap_Replace(t *testing.T) {
+	bm := New[string, int, string]()
+	assert.False(t, bm.Replace("keyA1", 1, "value1"))
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+
+	assert.False(t, bm.Replace("keyA1", 2, "value2"))
+	assert.True(t, bm.Replace("keyA1", 1, "value2"))
+
+	value, _ := bm.GetByKeyB(1)
+	assert.Equal(t, "value2", value)
+	_, exists := bm.GetByKeyB(2)
+	assert.False(t, exists)
+}
+
+func TestBiKeyMap_RekeyA(t *testing.T) {
+	bm := New[string, int, string]()
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+	require.NoError(t, bm.Put("keyA2", 2, "value2"))
+
+	require.NoError(t, bm.RekeyA("keyA1", "keyA3"))
+	assert.False(t, bm.HasKeyA("keyA1"))
+	value, exists := bm.GetByKeyA("keyA3")
+	assert.True(t, exists)
+	assert.Equal(t, "value1", value)
+	keyA, _ := bm.KeyAForKeyB(1)
+	assert.Equal(t, "keyA3", keyA)
+	assert.Equal(t, 2, bm.Size())
+
+	require.ErrorIs(t, bm.RekeyA("keyA1", "keyA4"), ErrKeyANotFound)
+	err := bm.RekeyA("keyA3", "keyA2")
+	require.ErrorIs(t, err, ErrKeyAConflict)
+	var conflictErr *ConflictError[string, int]
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, 2, conflictErr.ExistingKeyB)
+	require.NoError(t, bm.RekeyA("keyA3", "keyA3"))
+
+	// Failed rekeys must leave the map unchanged.
+	value, _ = bm.GetByKeyB(1)
+	assert.Equal(t, "value1", value)
+	value, _ = bm.GetByKeyB(2)
+	assert.Equal(t, "value2", value)
+}
+
+func TestBiKeyMap_RekeyB(t *testing.T) {
+	bm := New[string, int, string]()
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+	require.NoError(t, bm.Put("keyA2", 2, "value2"))
+
+	require.NoError(t, bm.RekeyB(1, 3))
+	assert.False(t, bm.HasKeyB(1))
+	value, exists := bm.GetByKeyB(3)
+	assert.True(t, exists)
+	assert.Equal(t, "value1", value)
+	keyB, _ := bm.KeyBForKeyA("keyA1")
+	assert.Equal(t, 3, keyB)
+	assert.Equal(t, 2, bm.Size())
+
+	require.ErrorIs(t, bm.RekeyB(1, 4), ErrKeyBNotFound)
+	err := bm.RekeyB(3, 2)
+	require.ErrorIs(t, err, ErrKeyBConflict)
+	var conflictErr *ConflictError[string, int]
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, "keyA2", conflictErr.ExistingKeyA)
+
+	value, _ = bm.GetByKeyA("keyA2")
+	assert.Equal(t, "value2", value)
+}
+
+func TestBiKeyMap_KeyLookups(t *testing.T) {
+	bm := New[string, int, string]()
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+	require.NoError(t, bm.Put("keyA2", 2, "value2"))
+
+	keyB, exists := bm.KeyBForKeyA("keyA2")
+	assert.True(t, exists)
+	assert.Equal(t, 2, keyB)
+	_, exists = bm.KeyBForKeyA("keyA3")
+	assert.False(t, exists)
+
+	keyA, exists := bm.KeyAForKeyB(1)
+	assert.True(t, exists)
+	assert.Equal(t, "keyA1", keyA)
+	_, exists = bm.KeyAForKeyB(3)
+	assert.False(t, exists)
+
+	assert.True(t, bm.HasKeyA("keyA1"))
+	assert.False(t, bm.HasKeyA("keyA3"))
+	assert.True(t, bm.HasKeyB(2))
+	assert.False(t, bm.HasKeyB(3))
+
+	assert.ElementsMatch(t, []string{"keyA1", "keyA2"}, bm.KeysA())
+	assert.ElementsMatch(t, []int{1, 2}, bm.KeysB())
 }
 
 func TestBiKeyMap_RemoveByKeyA(t *testing.T) {
@@ -109,13 +280,13 @@ func TestBiKeyMap_String(t *testing.T) {
 func TestBiKeyMap_RemoveByKeyA_NotFound(t *testing.T) {
 	bm := New[string, int, string]()
 	err := bm.RemoveByKeyA("nonExistentKey")
-	require.Error(t, err)
+	require.ErrorIs(t, err, ErrKeyANotFound)
 }
 
 func TestBiKeyMap_RemoveByKeyB_NotFound(t *testing.T) {
 	bm := New[string, int, string]()
 	err := bm.RemoveByKeyB(999)
-	require.Error(t, err)
+	require.ErrorIs(t, err, ErrKeyBNotFound)
 }
 func TestBiKeyMap_EmptyAndSize(t *testing.T) {
 	bm := New[string, int, string]()
@@ -168,6 +339,47 @@ func TestBiKeyMap_Values(t *testing.T) {
 
 // Benchmarks
 
+func TestBiKeyMap_WithInsertionOrder(t *testing.T) {
+	bm := New[string, int, string](WithInsertionOrder())
+	require.NoError(t, bm.Put("keyA3", 3, "value3"))
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+	require.NoError(t, bm.Put("keyA2", 2, "value2"))
+	require.NoError(t, bm.Put("keyA3", 3, "value30"))
+	assert.Equal(t, []string{"keyA3", "keyA1", "keyA2"}, bm.KeysA())
+	assert.Equal(t, []int{3, 1, 2}, bm.KeysB())
+	assert.Equal(t, []string{"value30", "value1", "value2"}, bm.Values())
+	assert.Equal(t, "BiKeyMap: [{keyA3 3 value30} {keyA1 1 value1} {keyA2 2 value2}]", bm.String())
+
+	require.NoError(t, bm.MoveToBack("keyA3"))
+	require.NoError(t, bm.MoveToFront("keyA2"))
+	assert.Equal(t, []string{"keyA2", "keyA1", "keyA3"}, bm.KeysA())
+	require.ErrorIs(t, bm.MoveToFront("keyA4"), ErrKeyANotFound)
+	require.ErrorIs(t, bm.MoveToBack("keyA4"), ErrKeyANotFound)
+
+	require.NoError(t, bm.RekeyA("keyA1", "keyA4"))
+	require.NoError(t, bm.RemoveByKeyB(2))
+	assert.True(t, bm.PutIfAbsent("keyA5", 5, "value5"))
+	// ForcePut replaces the entry of keyA3, so it moves to the back.
+	bm.ForcePut("keyA3", 6, "value6")
+	assert.Equal(t, []string{"keyA4", "keyA5", "keyA3"}, bm.KeysA())
+
+	for keyA := range bm.All() {
+		require.NoError(t, bm.RemoveByKeyA(keyA))
+	}
+	assert.True(t, bm.Empty())
+	require.NoError(t, bm.Put("keyA6", 6, "value6"))
+	bm.Clear()
+	require.NoError(t, bm.Put("keyA7", 7, "value7"))
+	assert.Equal(t, []string{"keyA7"}, bm.KeysA())
+}
+
+func TestBiKeyMap_MoveWithoutInsertionOrder(t *testing.T) {
+	bm := New[string, int, string]()
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+	require.ErrorIs(t, bm.MoveToFront("keyA1"), ErrNoInsertionOrder)
+	require.ErrorIs(t, bm.MoveToBack("keyA1"), ErrNoInsertionOrder)
+}
+
 var benchmarkSizes = []struct {
 	size int
 }{
@@ -225,3 +437,20 @@ func BenchmarkBiKeyMapRemove(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkBiKeyMapPutRemoveInsertionOrder(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size_%d", v.size), func(b *testing.B) {
+			m := New[string, int, string](WithInsertionOrder())
+			b.ResetTimer()
+			for range b.N {
+				for n := range v.size {
+					_ = m.Put(strconv.Itoa(n), n, strconv.Itoa(n))
+				}
+				for n := range v.size {
+					_ = m.RemoveByKeyB(n)
+				}
+			}
+		})
+	}
+}
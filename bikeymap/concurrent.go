@@ -1,48 +1,81 @@
 package bikeymap
 
 import (
-	"errors"
-	"fmt"
+	"iter"
 	"sync"
 )
 
 // ConcurrentBiKeyMap is the same as BiKeyMap, but it is safe for concurrent use.
 // It uses a RWMutex to protect the map from concurrent reads and writes.
 // Therefore, it is slower than BiKeyMap, but it is safe for concurrent use.
+// The wrapped BiKeyMap is not exposed, so it can only be accessed while holding the lock.
 type ConcurrentBiKeyMap[KeyA comparable, KeyB comparable, V any] struct {
-	mu sync.RWMutex
-	BiKeyMap[KeyA, KeyB, V]
+	mu   sync.RWMutex
+	base BiKeyMap[KeyA, KeyB, V]
 }
 
 // NewConcurrent creates a new instance of ConcurrentBiKeyMap.
-func NewConcurrent[KeyA comparable, KeyB comparable, V any]() *ConcurrentBiKeyMap[KeyA, KeyB, V] {
+func NewConcurrent[KeyA comparable, KeyB comparable, V any](opts ...Option) *ConcurrentBiKeyMap[KeyA, KeyB, V] {
 	return &ConcurrentBiKeyMap[KeyA, KeyB, V]{
-		BiKeyMap: BiKeyMap[KeyA, KeyB, V]{
-			dataByKeyA: make(map[KeyA]V),
-			keyAByKeyB: make(map[KeyB]KeyA),
-			keyBByKeyA: make(map[KeyA]KeyB),
-		},
+		base: *New[KeyA, KeyB, V](opts...),
 	}
 }
 
 // Put stores a value with two keys. It only fails if one of the keys is already set without the other.
+// The returned error is a *ConflictError.
 func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) Put(keyA KeyA, keyB KeyB, value V) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Check if one key is set without the other, or if they do not point to each other.
-	if existingKeyA, keyBExists := m.keyAByKeyB[keyB]; keyBExists && existingKeyA != keyA {
-		return errors.New("keyB is already set with a different keyA")
-	}
-	if existingKeyB, keyAExists := m.keyBByKeyA[keyA]; keyAExists && existingKeyB != keyB {
-		return errors.New("keyA is already set with a different keyB")
-	}
+	return m.base.Put(keyA, keyB, value)
+}
+
+// ForcePut stores a value with two keys like Put, but never fails.
+// Entries whose keyA or keyB is paired with a different key are removed first and returned.
+// The removal and the put happen atomically.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) ForcePut(keyA KeyA, keyB KeyB, value V) []Entry[KeyA, KeyB, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.base.ForcePut(keyA, keyB, value)
+}
+
+// PutIfAbsent stores a value with two keys only if neither of the keys is set.
+// It returns true if the value was stored.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) PutIfAbsent(keyA KeyA, keyB KeyB, value V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	// Put the new values for both keys.
-	m.dataByKeyA[keyA] = value
-	m.keyAByKeyB[keyB] = keyA
-	m.keyBByKeyA[keyA] = keyB
-	return nil
+	return m.base.PutIfAbsent(keyA, keyB, value)
+}
+
+// Replace replaces the value of an existing entry only if keyA is paired with keyB.
+// It returns true if the value was replaced.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) Replace(keyA KeyA, keyB KeyB, value V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.base.Replace(keyA, keyB, value)
+}
+
+// RekeyA moves the entry of oldKeyA to newKeyA, keeping its keyB and value.
+// It returns ErrKeyANotFound if oldKeyA does not exist
+// and a *ConflictError matching ErrKeyAConflict if newKeyA is already set.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) RekeyA(oldKeyA KeyA, newKeyA KeyA) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.base.RekeyA(oldKeyA, newKeyA)
+}
+
+// RekeyB moves the entry of oldKeyB to newKeyB, keeping its keyA and value.
+// It returns ErrKeyBNotFound if oldKeyB does not exist
+// and a *ConflictError matching ErrKeyBConflict if newKeyB is already set.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) RekeyB(oldKeyB KeyB, newKeyB KeyB) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.base.RekeyB(oldKeyB, newKeyB)
 }
 
 // GetByKeyA retrieves a value using the first key.
@@ -50,8 +83,7 @@ func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) GetByKeyA(keyA KeyA) (V, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	value, exists := m.dataByKeyA[keyA]
-	return value, exists
+	return m.base.GetByKeyA(keyA)
 }
 
 // GetByKeyB retrieves a value using the second key.
@@ -59,62 +91,151 @@ func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) GetByKeyB(keyB KeyB) (V, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	keyA, exists := m.keyAByKeyB[keyB]
-	if !exists {
-		var zero V
-		return zero, false
-	}
+	return m.base.GetByKeyB(keyB)
+}
 
-	value, exists := m.dataByKeyA[keyA]
-	return value, exists
+// KeyBForKeyA returns the second key paired with the first key.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) KeyBForKeyA(keyA KeyA) (KeyB, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.base.KeyBForKeyA(keyA)
 }
 
-// RemoveByKeyA removes a value using the first key, ensuring the corresponding second key is also deleted.
-func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) RemoveByKeyA(keyA KeyA) error {
+// KeyAForKeyB returns the first key paired with the second key.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) KeyAForKeyB(keyB KeyB) (KeyA, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.base.KeyAForKeyB(keyB)
+}
+
+// HasKeyA checks if the first key exists.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) HasKeyA(keyA KeyA) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.base.HasKeyA(keyA)
+}
+
+// HasKeyB checks if the second key exists.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) HasKeyB(keyB KeyB) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.base.HasKeyB(keyB)
+}
+
+// KeysA returns a slice of all first keys in the map.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) KeysA() []KeyA {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.base.KeysA()
+}
+
+// KeysB returns a slice of all second keys in the map.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) KeysB() []KeyB {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.base.KeysB()
+}
+
+// MoveToFront moves an entry to the front of the insertion order.
+// It returns ErrNoInsertionOrder if the map was not created with WithInsertionOrder
+// and ErrKeyANotFound if keyA does not exist.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) MoveToFront(keyA KeyA) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Verify that keyA exists and retrieve the associated keyB.
-	keyB, ok := m.keyBByKeyA[keyA]
-	if !ok {
-		return errors.New("keyA does not exist")
-	}
+	return m.base.MoveToFront(keyA)
+}
 
-	// Remove keyA, keyB, and the associated value.
-	delete(m.dataByKeyA, keyA)
-	delete(m.keyAByKeyB, keyB)
-	delete(m.keyBByKeyA, keyA)
+// MoveToBack moves an entry to the back of the insertion order.
+// It returns ErrNoInsertionOrder if the map was not created with WithInsertionOrder
+// and ErrKeyANotFound if keyA does not exist.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) MoveToBack(keyA KeyA) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	return nil
+	return m.base.MoveToBack(keyA)
+}
+
+// Inverse returns a view of the map with the roles of KeyA and KeyB swapped.
+// The view uses the locking of the map, so it is safe for concurrent use as well.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) Inverse() *Inverse[KeyB, KeyA, V] {
+	return &Inverse[KeyB, KeyA, V]{m: m}
+}
+
+// RemoveByKeyA removes a value using the first key, ensuring the corresponding second key is also deleted.
+// It returns ErrKeyANotFound if keyA does not exist.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) RemoveByKeyA(keyA KeyA) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.base.RemoveByKeyA(keyA)
 }
 
 // RemoveByKeyB removes a value using the second key, ensuring the corresponding first key is also deleted.
+// It returns ErrKeyBNotFound if keyB does not exist.
 func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) RemoveByKeyB(keyB KeyB) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Verify that keyB exists and retrieve the associated keyA.
-	keyA, ok := m.keyAByKeyB[keyB]
-	if !ok {
-		return errors.New("keyB does not exist")
-	}
+	return m.base.RemoveByKeyB(keyB)
+}
 
-	// Remove keyA, keyB, and the associated value.
-	delete(m.dataByKeyA, keyA)
-	delete(m.keyAByKeyB, keyB)
-	delete(m.keyBByKeyA, keyA)
+// Keys returns a slice of all first keys in the map. It is the same as KeysA.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) Keys() []KeyA {
+	return m.KeysA()
+}
+
+// Has checks if the first key exists. It is the same as HasKeyA.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) Has(keyA KeyA) bool {
+	return m.HasKeyA(keyA)
+}
 
-	return nil
+// Get retrieves a value using the first key. It is the same as GetByKeyA.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) Get(keyA KeyA) (V, bool) {
+	return m.GetByKeyA(keyA)
+}
+
+// All returns an iterator over all first keys and their values.
+// The entries are collected under a read lock when the iteration starts,
+// so the map may be modified while iterating.
+func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) All() iter.Seq2[KeyA, V] {
+	return func(yield func(KeyA, V) bool) {
+		m.mu.RLock()
+		keys := make([]KeyA, 0, len(m.base.dataByKeyA))
+		values := make([]V, 0, len(m.base.dataByKeyA))
+		for keyA, value := range m.base.All() {
+			keys = append(keys, keyA)
+			values = append(values, value)
+		}
+		m.mu.RUnlock()
+		for i, keyA := range keys {
+			if !yield(keyA, values[i]) {
+				return
+			}
+		}
+	}
 }
 
 // Empty checks if the map is empty.
 func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) Empty() bool {
-	return len(m.dataByKeyA) == 0
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.base.dataByKeyA) == 0
 }
 
 // Size returns the number of elements in the map.
 func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) Size() int {
-	return len(m.dataByKeyA)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return len(m.base.dataByKeyA)
 }
 
 // Values returns a slice of all values in the map.
@@ -122,11 +243,7 @@ func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) Values() []V {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	values := make([]V, 0, len(m.dataByKeyA))
-	for _, value := range m.dataByKeyA {
-		values = append(values, value)
-	}
-	return values
+	return m.base.Values()
 }
 
 // Clear removes all elements from the map.
@@ -134,15 +251,14 @@ func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) Clear() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	m.dataByKeyA = make(map[KeyA]V)
-	m.keyAByKeyB = make(map[KeyB]KeyA)
-	m.keyBByKeyA = make(map[KeyA]KeyB)
+	m.base.Clear()
 }
 
 // String returns a string representation of the map.
+// With WithInsertionOrder, the entries are listed in insertion order.
 func (m *ConcurrentBiKeyMap[KeyA, KeyB, V]) String() string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	return fmt.Sprintf("ConcurrentBiKeyMap: %v", m.dataByKeyA)
+	return "Concurrent" + m.base.String()
 }
@@ -2,6 +2,7 @@ package bikeymap
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
 	"sync"
 	"testing"
@@ -30,6 +31,38 @@ func TestConcurrentBiKeyMap_ImplementsContainerInterface(t *testing.T) {
 	}
 }
 
+func TestConcurrentBiKeyMap_KeyedContainer(t *testing.T) {
+	bm := NewConcurrent[string, int, string]()
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+	require.NoError(t, bm.Put("keyA2", 2, "value2"))
+
+	var keyed container.KeyedContainer[string, string] = bm
+	assert.ElementsMatch(t, []string{"keyA1", "keyA2"}, keyed.Keys())
+	assert.True(t, keyed.Has("keyA1"))
+	assert.False(t, keyed.Has("keyA3"))
+	value, exists := keyed.Get("keyA2")
+	assert.True(t, exists)
+	assert.Equal(t, "value2", value)
+	assert.Equal(t, map[string]string{"keyA1": "value1", "keyA2": "value2"}, container.Collect(keyed))
+}
+
+func TestConcurrentBiKeyMap_HidesUnderlyingMap(t *testing.T) {
+	concurrentType := reflect.TypeFor[ConcurrentBiKeyMap[string, int, string]]()
+	for i := range concurrentType.NumField() {
+		field := concurrentType.Field(i)
+		assert.False(t, field.IsExported(), "field %s allows access without locking", field.Name)
+	}
+
+	// Every method of BiKeyMap must be implemented with locking, as nothing is promoted.
+	pointerType := reflect.PointerTo(concurrentType)
+	baseType := reflect.TypeFor[*BiKeyMap[string, int, string]]()
+	for i := range baseType.NumMethod() {
+		method := baseType.Method(i)
+		_, exists := pointerType.MethodByName(method.Name)
+		assert.True(t, exists, "method %s is missing", method.Name)
+	}
+}
+
 func TestConcurrentBiKeyMap_SetAndGet(t *testing.T) {
 	bm := NewConcurrent[string, int, string]()
 
@@ -60,6 +93,80 @@ func TestConcurrentBiKeyMap_SetDuplicateKeys(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestConcurrentBiKeyMap_ForcePutPutIfAbsentReplace(t *testing.T) {
+	bm := NewConcurrent[string, int, string]()
+	assert.True(t, bm.PutIfAbsent("keyA1", 1, "value1"))
+	assert.False(t, bm.PutIfAbsent("keyA2", 1, "value2"))
+	assert.True(t, bm.Replace("keyA1", 1, "value10"))
+	assert.False(t, bm.Replace("keyA2", 2, "value2"))
+
+	evicted := bm.ForcePut("keyA2", 1, "value2")
+	assert.Equal(t, []Entry[string, int, string]{{KeyA: "keyA1", KeyB: 1, Value: "value10"}}, evicted)
+	value, _ := bm.GetByKeyB(1)
+	assert.Equal(t, "value2", value)
+}
+
+func TestConcurrentBiKeyMap_ForcePut_Concurrent(t *testing.T) {
+	bm := NewConcurrent[int, int, int]()
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bm.ForcePut(i%10, i%7, i)
+		}()
+	}
+	wg.Wait()
+
+	// Every remaining keyA must be paired with a keyB pointing back to it.
+	for keyA := range 10 {
+		if _, exists := bm.GetByKeyA(keyA); !exists {
+			continue
+		}
+		keyB := bm.base.keyBByKeyA[keyA]
+		assert.Equal(t, keyA, bm.base.keyAByKeyB[keyB])
+	}
+	assert.Equal(t, len(bm.base.keyAByKeyB), bm.Size())
+}
+
+func TestConcurrentBiKeyMap_KeyLookups(t *testing.T) {
+	bm := NewConcurrent[string, int, string]()
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+
+	keyB, exists := bm.KeyBForKeyA("keyA1")
+	assert.True(t, exists)
+	assert.Equal(t, 1, keyB)
+	keyA, exists := bm.KeyAForKeyB(1)
+	assert.True(t, exists)
+	assert.Equal(t, "keyA1", keyA)
+	assert.True(t, bm.HasKeyA("keyA1"))
+	assert.True(t, bm.HasKeyB(1))
+	assert.Equal(t, []string{"keyA1"}, bm.KeysA())
+	assert.Equal(t, []int{1}, bm.KeysB())
+
+	inverse := bm.Inverse()
+	require.NoError(t, inverse.Put(2, "keyA2", "value2"))
+	value, exists := bm.GetByKeyA("keyA2")
+	assert.True(t, exists)
+	assert.Equal(t, "value2", value)
+}
+
+func TestConcurrentBiKeyMap_Rekey(t *testing.T) {
+	bm := NewConcurrent[string, int, string]()
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+
+	require.NoError(t, bm.RekeyA("keyA1", "keyA2"))
+	require.NoError(t, bm.RekeyB(1, 2))
+	require.ErrorIs(t, bm.RekeyA("keyA1", "keyA3"), ErrKeyANotFound)
+	require.ErrorIs(t, bm.RekeyB(1, 3), ErrKeyBNotFound)
+
+	value, exists := bm.GetByKeyB(2)
+	assert.True(t, exists)
+	assert.Equal(t, "value1", value)
+	keyA, _ := bm.KeyAForKeyB(2)
+	assert.Equal(t, "keyA2", keyA)
+}
+
 func TestConcurrentBiKeyMap_RemoveByKeyA(t *testing.T) {
 	bm := NewConcurrent[string, int, string]()
 
@@ -99,6 +206,22 @@ func TestConcurrentBiKeyMap_RemoveByKeyB(t *testing.T) {
 	}
 }
 
+func TestConcurrentBiKeyMap_WithInsertionOrder(t *testing.T) {
+	bm := NewConcurrent[string, int, string](WithInsertionOrder())
+	require.NoError(t, bm.Put("keyA2", 2, "value2"))
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+	require.NoError(t, bm.Put("keyA3", 3, "value3"))
+	require.NoError(t, bm.MoveToFront("keyA3"))
+	require.NoError(t, bm.MoveToBack("keyA2"))
+	assert.Equal(t, []string{"keyA3", "keyA1", "keyA2"}, bm.KeysA())
+	assert.Equal(t, []int{3, 1, 2}, bm.KeysB())
+	assert.Equal(t, []string{"value3", "value1", "value2"}, bm.Values())
+	require.ErrorIs(t, bm.MoveToFront("keyA4"), ErrKeyANotFound)
+	bm.Clear()
+	assert.Empty(t, bm.KeysA())
+	require.ErrorIs(t, NewConcurrent[string, int, string]().MoveToBack("keyA1"), ErrNoInsertionOrder)
+}
+
 func TestConcurrentBiKeyMap_String(t *testing.T) {
 	bm := NewConcurrent[string, int, string]()
 	_ = bm.Put("keyA1", 1, "value1")
@@ -108,6 +231,14 @@ func TestConcurrentBiKeyMap_String(t *testing.T) {
 	}
 }
 
+func TestConcurrentBiKeyMap_String_InsertionOrder(t *testing.T) {
+	bm := NewConcurrent[string, int, int](WithInsertionOrder())
+	require.NoError(t, bm.Put("z", 1, 1))
+	require.NoError(t, bm.Put("a", 2, 2))
+	require.NoError(t, bm.Put("m", 3, 3))
+	assert.Equal(t, "ConcurrentBiKeyMap: [{z 1 1} {a 2 2} {m 3 3}]", bm.String())
+}
+
 func TestConcurrentBiKeyMap_RemoveByKeyA_NotFound(t *testing.T) {
 	bm := NewConcurrent[string, int, string]()
 	err := bm.RemoveByKeyA("nonExistentKey")
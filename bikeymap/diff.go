@@ -0,0 +1,137 @@
+package bikeymap
+
+// Entry is a single value of a BiKeyMap together with both of its keys.
+type Entry[KeyA any, KeyB any, V any] struct {
+	KeyA  KeyA
+	KeyB  KeyB
+	Value V
+}
+
+// Patch describes the differences between two BiKeyMaps.
+// It is created by Diff and can be applied to another map with Apply.
+type Patch[KeyA comparable, KeyB comparable, V any] struct {
+	// Added contains the entries which only exist in the target map.
+	Added []Entry[KeyA, KeyB, V]
+	// Removed contains the entries which only exist in the source map.
+	// An entry whose keyA is paired with a different keyB in the target map is removed and added again.
+	Removed []Entry[KeyA, KeyB, V]
+	// Changed contains the entries which exist in both maps but hold different values, with their new values.
+	Changed []Entry[KeyA, KeyB, V]
+}
+
+// Empty checks if the patch contains no changes.
+func (p *Patch[KeyA, KeyB, V]) Empty() bool {
+	return len(p.Added) == 0 && len(p.Removed) == 0 && len(p.Changed) == 0
+}
+
+// Apply applies the patch to the given map.
+// Removals are applied before additions, so a patch created by Diff(a, b) turns a into b.
+// It fails with a *ConflictError if an added or changed entry conflicts with an existing one.
+// If an error is returned, the map is left unchanged.
+func (p *Patch[KeyA, KeyB, V]) Apply(m *BiKeyMap[KeyA, KeyB, V]) error {
+	if err := p.check(m); err != nil {
+		return err
+	}
+
+	for _, entry := range p.Removed {
+		if keyB, exists := m.keyBByKeyA[entry.KeyA]; exists && keyB == entry.KeyB {
+			_ = m.RemoveByKeyA(entry.KeyA)
+		}
+	}
+	for _, entry := range p.Changed {
+		_ = m.Put(entry.KeyA, entry.KeyB, entry.Value)
+	}
+	for _, entry := range p.Added {
+		_ = m.Put(entry.KeyA, entry.KeyB, entry.Value)
+	}
+	return nil
+}
+
+// pairedKey is the key an entry is paired with while a patch is checked, or none if exists is false.
+type pairedKey[K any] struct {
+	key    K
+	exists bool
+}
+
+// check returns the error Apply would fail with, without changing the map.
+// The pairs removed and put by the patch are tracked on top of the pairs of the map.
+func (p *Patch[KeyA, KeyB, V]) check(m *BiKeyMap[KeyA, KeyB, V]) error {
+	keyBByKeyA := make(map[KeyA]pairedKey[KeyB])
+	keyAByKeyB := make(map[KeyB]pairedKey[KeyA])
+	lookupKeyB := func(keyA KeyA) (KeyB, bool) {
+		if paired, tracked := keyBByKeyA[keyA]; tracked {
+			return paired.key, paired.exists
+		}
+		keyB, exists := m.keyBByKeyA[keyA]
+		return keyB, exists
+	}
+	lookupKeyA := func(keyB KeyB) (KeyA, bool) {
+		if paired, tracked := keyAByKeyB[keyB]; tracked {
+			return paired.key, paired.exists
+		}
+		keyA, exists := m.keyAByKeyB[keyB]
+		return keyA, exists
+	}
+
+	for _, entry := range p.Removed {
+		if keyB, exists := lookupKeyB(entry.KeyA); exists && keyB == entry.KeyB {
+			keyBByKeyA[entry.KeyA] = pairedKey[KeyB]{}
+			keyAByKeyB[entry.KeyB] = pairedKey[KeyA]{}
+		}
+	}
+	for _, entries := range [][]Entry[KeyA, KeyB, V]{p.Changed, p.Added} {
+		for _, entry := range entries {
+			if existingKeyA, exists := lookupKeyA(entry.KeyB); exists && existingKeyA != entry.KeyA {
+				return newKeyBConflictError(entry.KeyA, entry.KeyB, existingKeyA)
+			}
+			if existingKeyB, exists := lookupKeyB(entry.KeyA); exists && existingKeyB != entry.KeyB {
+				return newKeyAConflictError(entry.KeyA, entry.KeyB, existingKeyB)
+			}
+			keyBByKeyA[entry.KeyA] = pairedKey[KeyB]{key: entry.KeyB, exists: true}
+			keyAByKeyB[entry.KeyB] = pairedKey[KeyA]{key: entry.KeyA, exists: true}
+		}
+	}
+	return nil
+}
+
+// Diff returns the changes needed to turn map a into map b.
+// The function eq is used to decide if two values are equal.
+func Diff[KeyA comparable, KeyB comparable, V any](a, b *BiKeyMap[KeyA, KeyB, V], eq func(V, V) bool) *Patch[KeyA, KeyB, V] {
+	patch := &Patch[KeyA, KeyB, V]{}
+
+	for keyA, valueA := range a.dataByKeyA {
+		keyB := a.keyBByKeyA[keyA]
+		otherKeyB, exists := b.keyBByKeyA[keyA]
+		switch {
+		case !exists || otherKeyB != keyB:
+			patch.Removed = append(patch.Removed, Entry[KeyA, KeyB, V]{KeyA: keyA, KeyB: keyB, Value: valueA})
+		case !eq(valueA, b.dataByKeyA[keyA]):
+			patch.Changed = append(patch.Changed, Entry[KeyA, KeyB, V]{KeyA: keyA, KeyB: keyB, Value: b.dataByKeyA[keyA]})
+		}
+	}
+	for keyA, valueB := range b.dataByKeyA {
+		keyB := b.keyBByKeyA[keyA]
+		if otherKeyB, exists := a.keyBByKeyA[keyA]; !exists || otherKeyB != keyB {
+			patch.Added = append(patch.Added, Entry[KeyA, KeyB, V]{KeyA: keyA, KeyB: keyB, Value: valueB})
+		}
+	}
+
+	return patch
+}
+
+// Equal checks if both maps contain the same key pairs and values.
+// The function eq is used to decide if two values are equal.
+func Equal[KeyA comparable, KeyB comparable, V any](a, b *BiKeyMap[KeyA, KeyB, V], eq func(V, V) bool) bool {
+	if len(a.dataByKeyA) != len(b.dataByKeyA) {
+		return false
+	}
+	for keyA, valueA := range a.dataByKeyA {
+		if otherKeyB, exists := b.keyBByKeyA[keyA]; !exists || otherKeyB != a.keyBByKeyA[keyA] {
+			return false
+		}
+		if !eq(valueA, b.dataByKeyA[keyA]) {
+			return false
+		}
+	}
+	return true
+}
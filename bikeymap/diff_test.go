@@ -0,0 +1,119 @@
+package bikeymap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stringEqual(a, b string) bool {
+	return a == b
+}
+
+func TestDiff(t *testing.T) {
+	a := New[string, int, string]()
+	require.NoError(t, a.Put("keyA1", 1, "value1"))
+	require.NoError(t, a.Put("keyA2", 2, "value2"))
+	require.NoError(t, a.Put("keyA3", 3, "value3"))
+
+	b := New[string, int, string]()
+	require.NoError(t, b.Put("keyA1", 1, "value1"))
+	require.NoError(t, b.Put("keyA2", 2, "value20"))
+	require.NoError(t, b.Put("keyA3", 30, "value3"))
+	require.NoError(t, b.Put("keyA4", 4, "value4"))
+
+	patch := Diff(a, b, stringEqual)
+	assert.ElementsMatch(t, []Entry[string, int, string]{
+		{KeyA: "keyA3", KeyB: 30, Value: "value3"},
+		{KeyA: "keyA4", KeyB: 4, Value: "value4"},
+	}, patch.Added)
+	assert.ElementsMatch(t, []Entry[string, int, string]{
+		{KeyA: "keyA3", KeyB: 3, Value: "value3"},
+	}, patch.Removed)
+	assert.ElementsMatch(t, []Entry[string, int, string]{
+		{KeyA: "keyA2", KeyB: 2, Value: "value20"},
+	}, patch.Changed)
+}
+
+func TestPatch_Apply(t *testing.T) {
+	a := New[string, int, string]()
+	require.NoError(t, a.Put("keyA1", 1, "value1"))
+	require.NoError(t, a.Put("keyA2", 2, "value2"))
+	require.NoError(t, a.Put("keyA3", 3, "value3"))
+
+	// keyB 1 moves from keyA1 to keyA2 and the other way around.
+	b := New[string, int, string]()
+	require.NoError(t, b.Put("keyA1", 2, "value1"))
+	require.NoError(t, b.Put("keyA2", 1, "value2"))
+	require.NoError(t, b.Put("keyA4", 4, "value4"))
+
+	patch := Diff(a, b, stringEqual)
+	require.NoError(t, patch.Apply(a))
+	assert.True(t, Equal(a, b, stringEqual))
+	assert.True(t, Diff(a, b, stringEqual).Empty())
+}
+
+func TestPatch_Apply_Conflict(t *testing.T) {
+	newMap := func() *BiKeyMap[string, int, string] {
+		m := New[string, int, string]()
+		require.NoError(t, m.Put("keyA1", 1, "value1"))
+		require.NoError(t, m.Put("keyA2", 2, "value2"))
+		return m
+	}
+
+	for name, patch := range map[string]*Patch[string, int, string]{
+		// keyB 1 is still paired with keyA1.
+		"existing entry": {
+			Removed: []Entry[string, int, string]{{KeyA: "keyA2", KeyB: 2, Value: "value2"}},
+			Changed: []Entry[string, int, string]{{KeyA: "keyA1", KeyB: 1, Value: "value10"}},
+			Added: []Entry[string, int, string]{
+				{KeyA: "keyA3", KeyB: 3, Value: "value3"},
+				{KeyA: "keyA4", KeyB: 1, Value: "value4"},
+			},
+		},
+		// keyB 3 is added twice by the patch itself.
+		"added entry": {
+			Removed: []Entry[string, int, string]{{KeyA: "keyA2", KeyB: 2, Value: "value2"}},
+			Added: []Entry[string, int, string]{
+				{KeyA: "keyA3", KeyB: 3, Value: "value3"},
+				{KeyA: "keyA4", KeyB: 3, Value: "value4"},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			m := newMap()
+			err := patch.Apply(m)
+			require.ErrorIs(t, err, ErrKeyBConflict)
+			var conflictErr *ConflictError[string, int]
+			require.ErrorAs(t, err, &conflictErr)
+			assert.Equal(t, "keyA4", conflictErr.KeyA)
+			assert.True(t, Equal(m, newMap(), stringEqual))
+		})
+	}
+
+	// keyB 2 is free once keyA2 is removed.
+	m := newMap()
+	patch := &Patch[string, int, string]{
+		Removed: []Entry[string, int, string]{{KeyA: "keyA2", KeyB: 2, Value: "value2"}},
+		Added:   []Entry[string, int, string]{{KeyA: "keyA3", KeyB: 2, Value: "value3"}},
+	}
+	require.NoError(t, patch.Apply(m))
+	keyA, _ := m.KeyAForKeyB(2)
+	assert.Equal(t, "keyA3", keyA)
+}
+
+func TestEqual(t *testing.T) {
+	a := New[string, int, string]()
+	require.NoError(t, a.Put("keyA1", 1, "value1"))
+	b := New[string, int, string]()
+	require.NoError(t, b.Put("keyA1", 1, "value1"))
+	assert.True(t, Equal(a, b, stringEqual))
+
+	require.NoError(t, b.Put("keyA1", 1, "value2"))
+	assert.False(t, Equal(a, b, stringEqual))
+
+	require.NoError(t, b.RemoveByKeyA("keyA1"))
+	require.NoError(t, b.Put("keyA1", 2, "value1"))
+	assert.False(t, Equal(a, b, stringEqual))
+}
@@ -0,0 +1,60 @@
+package bikeymap
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrKeyANotFound is returned if keyA does not exist.
+	ErrKeyANotFound = errors.New("keyA does not exist")
+	// ErrKeyBNotFound is returned if keyB does not exist.
+	ErrKeyBNotFound = errors.New("keyB does not exist")
+	// ErrConflict is matched by every *ConflictError.
+	ErrConflict = errors.New("key conflict")
+	// ErrKeyAConflict is matched by a *ConflictError if keyA is already set with a different keyB.
+	ErrKeyAConflict = fmt.Errorf("%w: keyA is already set with a different keyB", ErrConflict)
+	// ErrKeyBConflict is matched by a *ConflictError if keyB is already set with a different keyA.
+	ErrKeyBConflict = fmt.Errorf("%w: keyB is already set with a different keyA", ErrConflict)
+	// ErrMergeConflict is returned by Merge if the ErrorOnConflict strategy encounters a conflict.
+	ErrMergeConflict = errors.New("merge conflict")
+	// ErrNoInsertionOrder is returned by MoveToFront and MoveToBack if the map was not created with WithInsertionOrder.
+	ErrNoInsertionOrder = errors.New("map was not created with WithInsertionOrder")
+)
+
+// ConflictError is returned if a key is already set with a different key.
+// It matches ErrConflict and either ErrKeyAConflict or ErrKeyBConflict with errors.Is.
+type ConflictError[KeyA any, KeyB any] struct {
+	// Err is either ErrKeyAConflict or ErrKeyBConflict.
+	Err error
+	// KeyA and KeyB are the keys which should have been paired.
+	KeyA KeyA
+	KeyB KeyB
+	// ExistingKeyA is the keyA which KeyB is already set with, if Err is ErrKeyBConflict.
+	ExistingKeyA KeyA
+	// ExistingKeyB is the keyB which KeyA is already set with, if Err is ErrKeyAConflict.
+	ExistingKeyB KeyB
+}
+
+// newKeyAConflictError creates a ConflictError for keyA which is already set with existingKeyB.
+func newKeyAConflictError[KeyA any, KeyB any](keyA KeyA, keyB KeyB, existingKeyB KeyB) *ConflictError[KeyA, KeyB] {
+	return &ConflictError[KeyA, KeyB]{Err: ErrKeyAConflict, KeyA: keyA, KeyB: keyB, ExistingKeyB: existingKeyB}
+}
+
+// newKeyBConflictError creates a ConflictError for keyB which is already set with existingKeyA.
+func newKeyBConflictError[KeyA any, KeyB any](keyA KeyA, keyB KeyB, existingKeyA KeyA) *ConflictError[KeyA, KeyB] {
+	return &ConflictError[KeyA, KeyB]{Err: ErrKeyBConflict, KeyA: keyA, KeyB: keyB, ExistingKeyA: existingKeyA}
+}
+
+// Error returns the error message.
+func (e *ConflictError[KeyA, KeyB]) Error() string {
+	if errors.Is(e.Err, ErrKeyAConflict) {
+		return fmt.Sprintf("keyA %v is already set with a different keyB %v", e.KeyA, e.ExistingKeyB)
+	}
+	return fmt.Sprintf("keyB %v is already set with a different keyA %v", e.KeyB, e.ExistingKeyA)
+}
+
+// Unwrap returns the underlying sentinel error.
+func (e *ConflictError[KeyA, KeyB]) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,45 @@
+package bikeymap
+
+import (
+	"maps"
+
+	"github.com/aeimer/go-multikeymap/internal/bloom"
+)
+
+// newFilter creates a bloom filter for keys of type K, or returns nil if K cannot be hashed by the filter.
+func newFilter[K comparable]() *bloom.Filter[K] {
+	hash := bloom.HashFor[K]()
+	if hash == nil {
+		return nil
+	}
+	return bloom.New(hash)
+}
+
+// mayContain returns false if the key is definitely not in the filter. A nil filter may contain every key.
+func mayContain[K comparable](filter *bloom.Filter[K], key K) bool {
+	return filter == nil || filter.MayContain(key)
+}
+
+// filterAdd adds a key, which was just added to keys, to the filter.
+func filterAdd[K comparable, T any](filter *bloom.Filter[K], key K, keys map[K]T) {
+	if filter == nil {
+		return
+	}
+	filter.Add(key)
+	rebuildFilterIfNeeded(filter, keys)
+}
+
+// filterRemove records that a key was removed from keys.
+func filterRemove[K comparable, T any](filter *bloom.Filter[K], keys map[K]T) {
+	if filter == nil {
+		return
+	}
+	filter.Remove()
+	rebuildFilterIfNeeded(filter, keys)
+}
+
+func rebuildFilterIfNeeded[K comparable, T any](filter *bloom.Filter[K], keys map[K]T) {
+	if filter.NeedsRebuild() {
+		filter.Rebuild(maps.Keys(keys), len(keys))
+	}
+}
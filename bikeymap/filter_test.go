@@ -0,0 +1,101 @@
+package bikeymap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBiKeyMap_WithBloomFilter(t *testing.T) {
+	bm := New[string, int, int](WithBloomFilter())
+	require.NotNil(t, bm.filterA)
+	require.NotNil(t, bm.filterB)
+	const size = 1000
+	for n := range size {
+		require.NoError(t, bm.Put(strconv.Itoa(n), n, n))
+	}
+	for n := range size {
+		require.True(t, bm.HasKeyA(strconv.Itoa(n)))
+		require.True(t, bm.HasKeyB(n))
+		require.False(t, bm.HasKeyA(strconv.Itoa(n+size)))
+		require.False(t, bm.HasKeyB(n+size))
+	}
+
+	// Removing and rekeying most entries rebuilds the filters, the remaining keys must still be found.
+	for n := range size - 10 {
+		if n%2 == 0 {
+			require.NoError(t, bm.RemoveByKeyA(strconv.Itoa(n)))
+		} else {
+			require.NoError(t, bm.RekeyA(strconv.Itoa(n), "x"+strconv.Itoa(n)))
+			require.NoError(t, bm.RekeyB(n, -n))
+		}
+	}
+	for n := range size {
+		value, exists := bm.GetByKeyA(strconv.Itoa(n))
+		assert.Equal(t, n >= size-10, exists)
+		if exists {
+			assert.Equal(t, n, value)
+		}
+		keyA, exists := bm.KeyAForKeyB(-n)
+		assert.Equal(t, n%2 == 1 && n < size-10, exists)
+		if exists {
+			assert.Equal(t, "x"+strconv.Itoa(n), keyA)
+			keyB, _ := bm.KeyBForKeyA(keyA)
+			assert.Equal(t, -n, keyB)
+		}
+	}
+
+	bm.ForcePut("y", size-1, 0)
+	_, exists := bm.GetByKeyA(strconv.Itoa(size - 1))
+	assert.False(t, exists)
+	value, exists := bm.GetByKeyB(size - 1)
+	assert.True(t, exists)
+	assert.Equal(t, 0, value)
+
+	bm.Clear()
+	assert.False(t, bm.HasKeyA("y"))
+	assert.True(t, bm.PutIfAbsent("y", 1, 1))
+	assert.True(t, bm.HasKeyA("y"))
+}
+
+func TestBiKeyMap_WithBloomFilter_UnsupportedKey(t *testing.T) {
+	bm := New[float64, int, int](WithBloomFilter())
+	assert.Nil(t, bm.filterA)
+	assert.NotNil(t, bm.filterB)
+	require.NoError(t, bm.Put(1.5, 1, 1))
+	assert.True(t, bm.HasKeyA(1.5))
+	assert.False(t, bm.HasKeyA(2.5))
+}
+
+func BenchmarkBiKeyMapGetMiss(b *testing.B) {
+	modes := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "default"},
+		{name: "bloom", opts: []Option{WithBloomFilter()}},
+	}
+	for _, mode := range modes {
+		for _, v := range benchmarkSizes {
+			b.Run(fmt.Sprintf("%s/size_%d", mode.name, v.size), func(b *testing.B) {
+				m := New[string, int, int](mode.opts...)
+				misses := make([]string, v.size)
+				for n := range v.size {
+					// Keys of about 100 bytes, like URLs or content hashes.
+					_ = m.Put("hit"+strings.Repeat("x", 90)+strconv.Itoa(n), n, n)
+					misses[n] = "miss" + strings.Repeat("x", 90) + strconv.Itoa(n)
+				}
+				b.ResetTimer()
+				for range b.N {
+					for _, key := range misses {
+						m.GetByKeyA(key)
+					}
+				}
+			})
+		}
+	}
+}
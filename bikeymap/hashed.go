@@ -0,0 +1,355 @@
+package bikeymap
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/aeimer/go-multikeymap/internal/keyindex"
+)
+
+// HashedBiKeyMap is the same as BiKeyMap, but the keys are compared
+// with user-supplied hash and equality functions instead of ==.
+// Therefore, the keys can be of any type, e.g. []byte, or be canonicalized, e.g. case-insensitive strings.
+// Keys must not be modified after they were put.
+// If equal keys are put, the first one is kept and returned by the map.
+// HashedBiKeyMap is not safe for concurrent use.
+type HashedBiKeyMap[KeyA any, KeyB any, V any] struct {
+	keysA *keyindex.Index[KeyA]
+	keysB *keyindex.Index[KeyB]
+	base  BiKeyMap[keyindex.ID, keyindex.ID, V]
+}
+
+// NewWithHasher creates a new instance of HashedBiKeyMap.
+// Keys which are equal according to eqA or eqB must have the same hash.
+// Keys with the same hash are stored in one bucket and compared with the equality function.
+func NewWithHasher[KeyA any, KeyB any, V any](
+	hashA func(KeyA) uint64, eqA func(KeyA, KeyA) bool,
+	hashB func(KeyB) uint64, eqB func(KeyB, KeyB) bool,
+	opts ...Option,
+) *HashedBiKeyMap[KeyA, KeyB, V] {
+	return &HashedBiKeyMap[KeyA, KeyB, V]{
+		keysA: keyindex.New(hashA, eqA),
+		keysB: keyindex.New(hashB, eqB),
+		base:  *New[keyindex.ID, keyindex.ID, V](opts...),
+	}
+}
+
+// Put stores a value with two keys. It only fails if one of the keys is already set without the other.
+// The returned error is a *ConflictError.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) Put(keyA KeyA, keyB KeyB, value V) error {
+	idA, addedA := m.keysA.Add(keyA)
+	idB, addedB := m.keysB.Add(keyB)
+	if err := m.base.Put(idA, idB, value); err != nil {
+		err = m.conflictError(err)
+		m.release(idA, addedA, idB, addedB)
+		return err
+	}
+	return nil
+}
+
+// release deletes the IDs of keys which were only added for a failed operation.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) release(idA keyindex.ID, addedA bool, idB keyindex.ID, addedB bool) {
+	if addedA {
+		m.keysA.Delete(idA)
+	}
+	if addedB {
+		m.keysB.Delete(idB)
+	}
+}
+
+// conflictError converts a *ConflictError of the IDs into one of the keys.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) conflictError(err error) error {
+	var conflictErr *ConflictError[keyindex.ID, keyindex.ID]
+	if !errors.As(err, &conflictErr) {
+		return err
+	}
+	result := &ConflictError[KeyA, KeyB]{
+		Err:  conflictErr.Err,
+		KeyA: m.keysA.Key(conflictErr.KeyA),
+		KeyB: m.keysB.Key(conflictErr.KeyB),
+	}
+	if errors.Is(conflictErr.Err, ErrKeyAConflict) {
+		result.ExistingKeyB = m.keysB.Key(conflictErr.ExistingKeyB)
+	} else {
+		result.ExistingKeyA = m.keysA.Key(conflictErr.ExistingKeyA)
+	}
+	return result
+}
+
+// entry converts an Entry of the IDs into one of the keys.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) entry(entry Entry[keyindex.ID, keyindex.ID, V]) Entry[KeyA, KeyB, V] {
+	return Entry[KeyA, KeyB, V]{KeyA: m.keysA.Key(entry.KeyA), KeyB: m.keysB.Key(entry.KeyB), Value: entry.Value}
+}
+
+// ForcePut stores a value with two keys like Put, but never fails.
+// Entries whose keyA or keyB is paired with a different key are removed first and returned.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) ForcePut(keyA KeyA, keyB KeyB, value V) []Entry[KeyA, KeyB, V] {
+	idA, _ := m.keysA.Add(keyA)
+	idB, _ := m.keysB.Add(keyB)
+	evicted := m.base.ForcePut(idA, idB, value)
+	if evicted == nil {
+		return nil
+	}
+	entries := make([]Entry[KeyA, KeyB, V], 0, len(evicted))
+	for _, entry := range evicted {
+		entries = append(entries, m.entry(entry))
+		if !m.base.HasKeyA(entry.KeyA) {
+			m.keysA.Delete(entry.KeyA)
+		}
+		if !m.base.HasKeyB(entry.KeyB) {
+			m.keysB.Delete(entry.KeyB)
+		}
+	}
+	return entries
+}
+
+// PutIfAbsent stores a value with two keys only if neither of the keys is set.
+// It returns true if the value was stored.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) PutIfAbsent(keyA KeyA, keyB KeyB, value V) bool {
+	idA, addedA := m.keysA.Add(keyA)
+	idB, addedB := m.keysB.Add(keyB)
+	if !m.base.PutIfAbsent(idA, idB, value) {
+		m.release(idA, addedA, idB, addedB)
+		return false
+	}
+	return true
+}
+
+// Replace replaces the value of an existing entry only if keyA is paired with keyB.
+// It returns true if the value was replaced.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) Replace(keyA KeyA, keyB KeyB, value V) bool {
+	idA, existsA := m.keysA.Lookup(keyA)
+	idB, existsB := m.keysB.Lookup(keyB)
+	return existsA && existsB && m.base.Replace(idA, idB, value)
+}
+
+// RekeyA moves the entry of oldKeyA to newKeyA, keeping its keyB and value.
+// It returns ErrKeyANotFound if oldKeyA does not exist
+// and a *ConflictError matching ErrKeyAConflict if newKeyA is already set.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) RekeyA(oldKeyA KeyA, newKeyA KeyA) error {
+	oldID, exists := m.keysA.Lookup(oldKeyA)
+	if !exists {
+		return ErrKeyANotFound
+	}
+	newID, added := m.keysA.Add(newKeyA)
+	if err := m.base.RekeyA(oldID, newID); err != nil {
+		err = m.conflictError(err)
+		m.release(newID, added, 0, false)
+		return err
+	}
+	if newID != oldID {
+		m.keysA.Delete(oldID)
+	}
+	return nil
+}
+
+// RekeyB moves the entry of oldKeyB to newKeyB, keeping its keyA and value.
+// It returns ErrKeyBNotFound if oldKeyB does not exist
+// and a *ConflictError matching ErrKeyBConflict if newKeyB is already set.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) RekeyB(oldKeyB KeyB, newKeyB KeyB) error {
+	oldID, exists := m.keysB.Lookup(oldKeyB)
+	if !exists {
+		return ErrKeyBNotFound
+	}
+	newID, added := m.keysB.Add(newKeyB)
+	if err := m.base.RekeyB(oldID, newID); err != nil {
+		err = m.conflictError(err)
+		m.release(0, false, newID, added)
+		return err
+	}
+	if newID != oldID {
+		m.keysB.Delete(oldID)
+	}
+	return nil
+}
+
+// GetByKeyA retrieves a value using the first key.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) GetByKeyA(keyA KeyA) (V, bool) {
+	idA, exists := m.keysA.Lookup(keyA)
+	if !exists {
+		var zero V
+		return zero, false
+	}
+	return m.base.GetByKeyA(idA)
+}
+
+// GetByKeyB retrieves a value using the second key.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) GetByKeyB(keyB KeyB) (V, bool) {
+	idB, exists := m.keysB.Lookup(keyB)
+	if !exists {
+		var zero V
+		return zero, false
+	}
+	return m.base.GetByKeyB(idB)
+}
+
+// KeyBForKeyA returns the second key paired with the first key.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) KeyBForKeyA(keyA KeyA) (KeyB, bool) {
+	if idA, exists := m.keysA.Lookup(keyA); exists {
+		if idB, exists := m.base.KeyBForKeyA(idA); exists {
+			return m.keysB.Key(idB), true
+		}
+	}
+	var zero KeyB
+	return zero, false
+}
+
+// KeyAForKeyB returns the first key paired with the second key.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) KeyAForKeyB(keyB KeyB) (KeyA, bool) {
+	if idB, exists := m.keysB.Lookup(keyB); exists {
+		if idA, exists := m.base.KeyAForKeyB(idB); exists {
+			return m.keysA.Key(idA), true
+		}
+	}
+	var zero KeyA
+	return zero, false
+}
+
+// HasKeyA checks if the first key exists.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) HasKeyA(keyA KeyA) bool {
+	_, exists := m.keysA.Lookup(keyA)
+	return exists
+}
+
+// HasKeyB checks if the second key exists.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) HasKeyB(keyB KeyB) bool {
+	_, exists := m.keysB.Lookup(keyB)
+	return exists
+}
+
+// KeysA returns a slice of all first keys in the map.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) KeysA() []KeyA {
+	keys := make([]KeyA, 0, m.base.Size())
+	for _, idA := range m.base.KeysA() {
+		keys = append(keys, m.keysA.Key(idA))
+	}
+	return keys
+}
+
+// KeysB returns a slice of all second keys in the map.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) KeysB() []KeyB {
+	keys := make([]KeyB, 0, m.base.Size())
+	for _, idB := range m.base.KeysB() {
+		keys = append(keys, m.keysB.Key(idB))
+	}
+	return keys
+}
+
+// Inverse returns a view of the map with the roles of KeyA and KeyB swapped.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) Inverse() *Inverse[KeyB, KeyA, V] {
+	return &Inverse[KeyB, KeyA, V]{m: m}
+}
+
+// RemoveByKeyA removes a value using the first key, ensuring the corresponding second key is also deleted.
+// It returns ErrKeyANotFound if keyA does not exist.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) RemoveByKeyA(keyA KeyA) error {
+	idA, exists := m.keysA.Lookup(keyA)
+	if !exists {
+		return ErrKeyANotFound
+	}
+	idB, _ := m.base.KeyBForKeyA(idA)
+	if err := m.base.RemoveByKeyA(idA); err != nil {
+		return err
+	}
+	m.keysA.Delete(idA)
+	m.keysB.Delete(idB)
+	return nil
+}
+
+// RemoveByKeyB removes a value using the second key, ensuring the corresponding first key is also deleted.
+// It returns ErrKeyBNotFound if keyB does not exist.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) RemoveByKeyB(keyB KeyB) error {
+	idB, exists := m.keysB.Lookup(keyB)
+	if !exists {
+		return ErrKeyBNotFound
+	}
+	idA, _ := m.base.KeyAForKeyB(idB)
+	if err := m.base.RemoveByKeyB(idB); err != nil {
+		return err
+	}
+	m.keysA.Delete(idA)
+	m.keysB.Delete(idB)
+	return nil
+}
+
+// Keys returns a slice of all first keys in the map. It is the same as KeysA.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) Keys() []KeyA {
+	return m.KeysA()
+}
+
+// Has checks if the first key exists. It is the same as HasKeyA.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) Has(keyA KeyA) bool {
+	return m.HasKeyA(keyA)
+}
+
+// Get retrieves a value using the first key. It is the same as GetByKeyA.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) Get(keyA KeyA) (V, bool) {
+	return m.GetByKeyA(keyA)
+}
+
+// All returns an iterator over all first keys and their values.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) All() iter.Seq2[KeyA, V] {
+	return func(yield func(KeyA, V) bool) {
+		for idA, value := range m.base.All() {
+			if !yield(m.keysA.Key(idA), value) {
+				return
+			}
+		}
+	}
+}
+
+// MoveToFront moves an entry to the front of the insertion order.
+// It returns ErrNoInsertionOrder if the map was not created with WithInsertionOrder
+// and ErrKeyANotFound if keyA does not exist.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) MoveToFront(keyA KeyA) error {
+	idA, exists := m.keysA.Lookup(keyA)
+	if !exists && m.base.insertionOrder != nil {
+		return ErrKeyANotFound
+	}
+	return m.base.MoveToFront(idA)
+}
+
+// MoveToBack moves an entry to the back of the insertion order.
+// It returns ErrNoInsertionOrder if the map was not created with WithInsertionOrder
+// and ErrKeyANotFound if keyA does not exist.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) MoveToBack(keyA KeyA) error {
+	idA, exists := m.keysA.Lookup(keyA)
+	if !exists && m.base.insertionOrder != nil {
+		return ErrKeyANotFound
+	}
+	return m.base.MoveToBack(idA)
+}
+
+// Empty checks if the map is empty.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) Empty() bool {
+	return m.base.Empty()
+}
+
+// Size returns the number of elements in the map.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) Size() int {
+	return m.base.Size()
+}
+
+// Values returns a slice of all values in the map.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) Values() []V {
+	return m.base.Values()
+}
+
+// Clear removes all elements from the map.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) Clear() {
+	m.base.Clear()
+	m.keysA.Clear()
+	m.keysB.Clear()
+}
+
+// String returns a string representation of the map.
+func (m *HashedBiKeyMap[KeyA, KeyB, V]) String() string {
+	entries := make([]Entry[KeyA, KeyB, V], 0, m.base.Size())
+	for idA, value := range m.base.All() {
+		entries = append(entries, Entry[KeyA, KeyB, V]{
+			KeyA: m.keysA.Key(idA), KeyB: m.keysB.Key(m.base.keyBByKeyA[idA]), Value: value,
+		})
+	}
+	return fmt.Sprintf("HashedBiKeyMap: %v", entries)
+}
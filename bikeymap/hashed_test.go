@@ -0,0 +1,137 @@
+package bikeymap
+
+import (
+	"bytes"
+	"fmt"
+	"hash/maphash"
+	"strings"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSeed = maphash.MakeSeed()
+
+func hashBytes(key []byte) uint64 {
+	return maphash.Bytes(testSeed, key)
+}
+
+func hashInt(key int) uint64 {
+	return uint64(key) * 0x9e3779b97f4a7c15
+}
+
+func equalInt(a, b int) bool {
+	return a == b
+}
+
+// collidingHash puts all keys into the same bucket.
+func collidingHash([]byte) uint64 {
+	return 42
+}
+
+func ExampleNewWithHasher() {
+	// Case-insensitive usernames as keyA.
+	bm := NewWithHasher[string, int, string](
+		func(key string) uint64 { return maphash.String(testSeed, strings.ToLower(key)) },
+		strings.EqualFold,
+		hashInt, equalInt,
+	)
+	_ = bm.Put("Alice", 1, "alice@example.com")
+
+	value, _ := bm.GetByKeyA("ALICE")
+	fmt.Println(value)
+	keyA, _ := bm.KeyAForKeyB(1)
+	fmt.Println(keyA)
+	// Output:
+	// alice@example.com
+	// Alice
+}
+
+func TestHashedBiKeyMap_ImplementsContainerInterface(t *testing.T) {
+	instance := NewWithHasher[[]byte, int, int](hashBytes, bytes.Equal, hashInt, equalInt)
+	if _, ok := any(instance).(container.KeyedContainer[[]byte, int]); !ok {
+		t.Error("HashedBiKeyMap does not implement the KeyedContainer interface")
+	}
+}
+
+func TestHashedBiKeyMap(t *testing.T) {
+	for name, hash := range map[string]func([]byte) uint64{"Hash": hashBytes, "Collisions": collidingHash} {
+		t.Run(name, func(t *testing.T) {
+			bm := NewWithHasher[[]byte, int, string](hash, bytes.Equal, hashInt, equalInt)
+			require.NoError(t, bm.Put([]byte("keyA1"), 1, "value1"))
+			require.NoError(t, bm.Put([]byte("keyA2"), 2, "value2"))
+			require.NoError(t, bm.Put([]byte("keyA1"), 1, "value10"))
+
+			value, exists := bm.GetByKeyA([]byte("keyA1"))
+			assert.True(t, exists)
+			assert.Equal(t, "value10", value)
+			value, exists = bm.GetByKeyB(2)
+			assert.True(t, exists)
+			assert.Equal(t, "value2", value)
+			keyA, _ := bm.KeyAForKeyB(2)
+			assert.Equal(t, []byte("keyA2"), keyA)
+			assert.ElementsMatch(t, [][]byte{[]byte("keyA1"), []byte("keyA2")}, bm.KeysA())
+
+			err := bm.Put([]byte("keyA3"), 1, "value3")
+			require.ErrorIs(t, err, ErrKeyBConflict)
+			var conflictErr *ConflictError[[]byte, int]
+			require.ErrorAs(t, err, &conflictErr)
+			assert.Equal(t, []byte("keyA3"), conflictErr.KeyA)
+			assert.Equal(t, []byte("keyA1"), conflictErr.ExistingKeyA)
+			assert.False(t, bm.HasKeyA([]byte("keyA3")))
+
+			require.NoError(t, bm.RekeyA([]byte("keyA1"), []byte("keyA3")))
+			require.NoError(t, bm.RekeyB(1, 3))
+			require.ErrorIs(t, bm.RekeyA([]byte("keyA3"), []byte("keyA2")), ErrKeyAConflict)
+			value, _ = bm.GetByKeyB(3)
+			assert.Equal(t, "value10", value)
+
+			require.NoError(t, bm.RemoveByKeyB(3))
+			require.ErrorIs(t, bm.RemoveByKeyA([]byte("keyA3")), ErrKeyANotFound)
+			assert.Equal(t, 1, bm.Size())
+			assert.Equal(t, 1, bm.keysA.Len())
+			assert.Equal(t, 1, bm.keysB.Len())
+		})
+	}
+}
+
+func TestHashedBiKeyMap_PutVariants(t *testing.T) {
+	bm := NewWithHasher[[]byte, int, string](hashBytes, bytes.Equal, hashInt, equalInt)
+	assert.True(t, bm.PutIfAbsent([]byte("keyA1"), 1, "value1"))
+	assert.False(t, bm.PutIfAbsent([]byte("keyA2"), 1, "value2"))
+	assert.False(t, bm.HasKeyA([]byte("keyA2")))
+	assert.True(t, bm.Replace([]byte("keyA1"), 1, "value10"))
+	assert.False(t, bm.Replace([]byte("keyA1"), 2, "value2"))
+
+	evicted := bm.ForcePut([]byte("keyA2"), 1, "value2")
+	assert.Equal(t, []Entry[[]byte, int, string]{{[]byte("keyA1"), 1, "value10"}}, evicted)
+	assert.False(t, bm.HasKeyA([]byte("keyA1")))
+	assert.Equal(t, 1, bm.keysA.Len())
+
+	inverse := bm.Inverse()
+	value, exists := inverse.GetByKeyA(1)
+	assert.True(t, exists)
+	assert.Equal(t, "value2", value)
+	assert.Equal(t, map[int]string{1: "value2"}, container.Collect(inverse))
+	assert.Equal(t, "HashedBiKeyMap: [{[107 101 121 65 50] 1 value2}]", bm.String())
+
+	bm.Clear()
+	assert.True(t, bm.Empty())
+	assert.Equal(t, 0, bm.keysB.Len())
+}
+
+func TestHashedBiKeyMap_WithInsertionOrder(t *testing.T) {
+	bm := NewWithHasher[[]byte, int, string](hashBytes, bytes.Equal, hashInt, equalInt, WithInsertionOrder())
+	require.NoError(t, bm.Put([]byte("keyA2"), 2, "value2"))
+	require.NoError(t, bm.Put([]byte("keyA1"), 1, "value1"))
+	require.NoError(t, bm.Put([]byte("keyA3"), 3, "value3"))
+	require.NoError(t, bm.MoveToFront([]byte("keyA3")))
+	require.NoError(t, bm.MoveToBack([]byte("keyA2")))
+	assert.Equal(t, [][]byte{[]byte("keyA3"), []byte("keyA1"), []byte("keyA2")}, bm.KeysA())
+	assert.Equal(t, []int{3, 1, 2}, bm.KeysB())
+	assert.Equal(t, "HashedBiKeyMap: [{[107 101 121 65 51] 3 value3} {[107 101 121 65 49] 1 value1} {[107 101 121 65 50] 2 value2}]", bm.String())
+	require.ErrorIs(t, bm.MoveToFront([]byte("keyA4")), ErrKeyANotFound)
+	require.ErrorIs(t, NewWithHasher[[]byte, int, string](hashBytes, bytes.Equal, hashInt, equalInt).MoveToBack([]byte("keyA1")), ErrNoInsertionOrder)
+}
@@ -0,0 +1,36 @@
+package bikeymap
+
+import (
+	"github.com/aeimer/go-multikeymap/container"
+)
+
+// Interface is implemented by BiKeyMap, ConcurrentBiKeyMap, HashedBiKeyMap, OrderedBiKeyMap and Inverse.
+// It allows code to be generic over all implementations, e.g. for decorators or mocks.
+// Inverse is not part of Interface, as the method returns the concrete view type.
+type Interface[KeyA any, KeyB any, V any] interface {
+	container.KeyedContainer[KeyA, V]
+	Put(keyA KeyA, keyB KeyB, value V) error
+	ForcePut(keyA KeyA, keyB KeyB, value V) []Entry[KeyA, KeyB, V]
+	PutIfAbsent(keyA KeyA, keyB KeyB, value V) bool
+	Replace(keyA KeyA, keyB KeyB, value V) bool
+	RekeyA(oldKeyA KeyA, newKeyA KeyA) error
+	RekeyB(oldKeyB KeyB, newKeyB KeyB) error
+	GetByKeyA(keyA KeyA) (V, bool)
+	GetByKeyB(keyB KeyB) (V, bool)
+	KeyBForKeyA(keyA KeyA) (KeyB, bool)
+	KeyAForKeyB(keyB KeyB) (KeyA, bool)
+	HasKeyA(keyA KeyA) bool
+	HasKeyB(keyB KeyB) bool
+	KeysA() []KeyA
+	KeysB() []KeyB
+	RemoveByKeyA(keyA KeyA) error
+	RemoveByKeyB(keyB KeyB) error
+}
+
+var (
+	_ Interface[string, int, int] = (*BiKeyMap[string, int, int])(nil)
+	_ Interface[string, int, int] = (*ConcurrentBiKeyMap[string, int, int])(nil)
+	_ Interface[string, int, int] = (*Inverse[string, int, int])(nil)
+	_ Interface[[]byte, int, int] = (*HashedBiKeyMap[[]byte, int, int])(nil)
+	_ Interface[string, int, int] = (*OrderedBiKeyMap[string, int, int])(nil)
+)
@@ -0,0 +1,34 @@
+package bikeymap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterface(t *testing.T) {
+	implementations := map[string]func() Interface[string, int, string]{
+		"BiKeyMap":           func() Interface[string, int, string] { return New[string, int, string]() },
+		"ConcurrentBiKeyMap": func() Interface[string, int, string] { return NewConcurrent[string, int, string]() },
+		"OrderedBiKeyMap":    func() Interface[string, int, string] { return NewOrdered[string, int, string]() },
+		"Inverse": func() Interface[string, int, string] {
+			return New[int, string, string]().Inverse()
+		},
+	}
+	for name, newMap := range implementations {
+		t.Run(name, func(t *testing.T) {
+			bm := newMap()
+			require.NoError(t, bm.Put("keyA1", 1, "value1"))
+			require.ErrorIs(t, bm.Put("keyA2", 1, "value2"), ErrConflict)
+			require.NoError(t, bm.RekeyA("keyA1", "keyA2"))
+
+			value, exists := bm.GetByKeyB(1)
+			assert.True(t, exists)
+			assert.Equal(t, "value1", value)
+			assert.Equal(t, []string{"keyA2"}, bm.KeysA())
+			require.NoError(t, bm.RemoveByKeyA("keyA2"))
+			assert.True(t, bm.Empty())
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package bikeymap
+
+import "iter"
+
+// Inverse is a view of a BiKeyMap, ConcurrentBiKeyMap, HashedBiKeyMap or OrderedBiKeyMap
+// with the roles of KeyA and KeyB swapped.
+// It does not copy the map, so all changes of the view are applied to the map and vice versa.
+// Errors are returned unchanged from the map, so they name the keys from the perspective of the map.
+// It implements container/Container.
+type Inverse[KeyA any, KeyB any, V any] struct {
+	m Interface[KeyB, KeyA, V]
+}
+
+// Put stores a value with two keys. It only fails if one of the keys is already set without the other.
+func (i *Inverse[KeyA, KeyB, V]) Put(keyA KeyA, keyB KeyB, value V) error {
+	return i.m.Put(keyB, keyA, value)
+}
+
+// ForcePut stores a value with two keys like Put, but never fails.
+// Entries whose keyA or keyB is paired with a different key are removed first and returned.
+func (i *Inverse[KeyA, KeyB, V]) ForcePut(keyA KeyA, keyB KeyB, value V) []Entry[KeyA, KeyB, V] {
+	evicted := i.m.ForcePut(keyB, keyA, value)
+	if evicted == nil {
+		return nil
+	}
+	entries := make([]Entry[KeyA, KeyB, V], 0, len(evicted))
+	for _, entry := range evicted {
+		entries = append(entries, Entry[KeyA, KeyB, V]{KeyA: entry.KeyB, KeyB: entry.KeyA, Value: entry.Value})
+	}
+	return entries
+}
+
+// PutIfAbsent stores a value with two keys only if neither of the keys is set.
+// It returns true if the value was stored.
+func (i *Inverse[KeyA, KeyB, V]) PutIfAbsent(keyA KeyA, keyB KeyB, value V) bool {
+	return i.m.PutIfAbsent(keyB, keyA, value)
+}
+
+// Replace replaces the value of an existing entry only if keyA is paired with keyB.
+// It returns true if the value was replaced.
+func (i *Inverse[KeyA, KeyB, V]) Replace(keyA KeyA, keyB KeyB, value V) bool {
+	return i.m.Replace(keyB, keyA, value)
+}
+
+// RekeyA moves the entry of oldKeyA to newKeyA, keeping its keyB and value.
+func (i *Inverse[KeyA, KeyB, V]) RekeyA(oldKeyA KeyA, newKeyA KeyA) error {
+	return i.m.RekeyB(oldKeyA, newKeyA)
+}
+
+// RekeyB moves the entry of oldKeyB to newKeyB, keeping its keyA and value.
+func (i *Inverse[KeyA, KeyB, V]) RekeyB(oldKeyB KeyB, newKeyB KeyB) error {
+	return i.m.RekeyA(oldKeyB, newKeyB)
+}
+
+// GetByKeyA retrieves a value using the first key.
+func (i *Inverse[KeyA, KeyB, V]) GetByKeyA(keyA KeyA) (V, bool) {
+	return i.m.GetByKeyB(keyA)
+}
+
+// GetByKeyB retrieves a value using the second key.
+func (i *Inverse[KeyA, KeyB, V]) GetByKeyB(keyB KeyB) (V, bool) {
+	return i.m.GetByKeyA(keyB)
+}
+
+// KeyBForKeyA returns the second key paired with the first key.
+func (i *Inverse[KeyA, KeyB, V]) KeyBForKeyA(keyA KeyA) (KeyB, bool) {
+	return i.m.KeyAForKeyB(keyA)
+}
+
+// KeyAForKeyB returns the first key paired with the second key.
+func (i *Inverse[KeyA, KeyB, V]) KeyAForKeyB(keyB KeyB) (KeyA, bool) {
+	return i.m.KeyBForKeyA(keyB)
+}
+
+// HasKeyA checks if the first key exists.
+func (i *Inverse[KeyA, KeyB, V]) HasKeyA(keyA KeyA) bool {
+	return i.m.HasKeyB(keyA)
+}
+
+// HasKeyB checks if the second key exists.
+func (i *Inverse[KeyA, KeyB, V]) HasKeyB(keyB KeyB) bool {
+	return i.m.HasKeyA(keyB)
+}
+
+// KeysA returns a slice of all first keys in the map.
+func (i *Inverse[KeyA, KeyB, V]) KeysA() []KeyA {
+	return i.m.KeysB()
+}
+
+// KeysB returns a slice of all second keys in the map.
+func (i *Inverse[KeyA, KeyB, V]) KeysB() []KeyB {
+	return i.m.KeysA()
+}
+
+// RemoveByKeyA removes a value using the first key, ensuring the corresponding second key is also deleted.
+func (i *Inverse[KeyA, KeyB, V]) RemoveByKeyA(keyA KeyA) error {
+	return i.m.RemoveByKeyB(keyA)
+}
+
+// RemoveByKeyB removes a value using the second key, ensuring the corresponding first key is also deleted.
+func (i *Inverse[KeyA, KeyB, V]) RemoveByKeyB(keyB KeyB) error {
+	return i.m.RemoveByKeyA(keyB)
+}
+
+// Keys returns a slice of all first keys in the map. It is the same as KeysA.
+func (i *Inverse[KeyA, KeyB, V]) Keys() []KeyA {
+	return i.KeysA()
+}
+
+// Has checks if the first key exists. It is the same as HasKeyA.
+func (i *Inverse[KeyA, KeyB, V]) Has(keyA KeyA) bool {
+	return i.HasKeyA(keyA)
+}
+
+// Get retrieves a value using the first key. It is the same as GetByKeyA.
+func (i *Inverse[KeyA, KeyB, V]) Get(keyA KeyA) (V, bool) {
+	return i.GetByKeyA(keyA)
+}
+
+// All returns an iterator over all first keys and their values.
+// The keys are collected when the iteration starts, keys which are removed while iterating are skipped.
+func (i *Inverse[KeyA, KeyB, V]) All() iter.Seq2[KeyA, V] {
+	return func(yield func(KeyA, V) bool) {
+		for _, keyA := range i.KeysA() {
+			value, exists := i.GetByKeyA(keyA)
+			if exists && !yield(keyA, value) {
+				return
+			}
+		}
+	}
+}
+
+// Empty checks if the map is empty.
+func (i *Inverse[KeyA, KeyB, V]) Empty() bool {
+	return i.m.Empty()
+}
+
+// Size returns the number of elements in the map.
+func (i *Inverse[KeyA, KeyB, V]) Size() int {
+	return i.m.Size()
+}
+
+// Values returns a slice of all values in the map.
+func (i *Inverse[KeyA, KeyB, V]) Values() []V {
+	return i.m.Values()
+}
+
+// Clear removes all elements from the map.
+func (i *Inverse[KeyA, KeyB, V]) Clear() {
+	i.m.Clear()
+}
+
+// String returns a string representation of the map.
+func (i *Inverse[KeyA, KeyB, V]) String() string {
+	return "Inverse of " + i.m.String()
+}
@@ -0,0 +1,115 @@
+package bikeymap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleBiKeyMap_Inverse() {
+	// keyA: internal ID, keyB: external ID
+	bm := New[int, string, string]()
+	_ = bm.Put(1, "ext-1", "value1")
+
+	byExternalID := bm.Inverse()
+	internalID, _ := byExternalID.KeyBForKeyA("ext-1")
+	fmt.Printf("internal ID: %v\n", internalID)
+	// Output:
+	// internal ID: 1
+}
+
+func TestInverse_ImplementsContainerInterface(t *testing.T) {
+	instance := New[int, int, int]().Inverse()
+	if _, ok := any(instance).(container.Container[int]); !ok {
+		t.Error("Inverse does not implement the Container interface")
+	}
+}
+
+func TestInverse_KeyedContainer(t *testing.T) {
+	bm := New[string, int, string]()
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+	require.NoError(t, bm.Put("keyA2", 2, "value2"))
+
+	var keyed container.KeyedContainer[int, string] = bm.Inverse()
+	assert.ElementsMatch(t, []int{1, 2}, keyed.Keys())
+	assert.True(t, keyed.Has(1))
+	assert.False(t, keyed.Has(3))
+	value, exists := keyed.Get(2)
+	assert.True(t, exists)
+	assert.Equal(t, "value2", value)
+	assert.Equal(t, map[int]string{1: "value1", 2: "value2"}, container.Collect(keyed))
+}
+
+func TestInverse(t *testing.T) {
+	bm := New[string, int, string]()
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+	inverse := bm.Inverse()
+
+	value, exists := inverse.GetByKeyA(1)
+	assert.True(t, exists)
+	assert.Equal(t, "value1", value)
+	value, exists = inverse.GetByKeyB("keyA1")
+	assert.True(t, exists)
+	assert.Equal(t, "value1", value)
+
+	keyB, _ := inverse.KeyBForKeyA(1)
+	assert.Equal(t, "keyA1", keyB)
+	keyA, _ := inverse.KeyAForKeyB("keyA1")
+	assert.Equal(t, 1, keyA)
+	assert.True(t, inverse.HasKeyA(1))
+	assert.True(t, inverse.HasKeyB("keyA1"))
+	assert.Equal(t, []int{1}, inverse.KeysA())
+	assert.Equal(t, []string{"keyA1"}, inverse.KeysB())
+
+	// Changes of the view are visible in the map and vice versa.
+	require.NoError(t, inverse.Put(2, "keyA2", "value2"))
+	value, _ = bm.GetByKeyA("keyA2")
+	assert.Equal(t, "value2", value)
+	require.NoError(t, bm.Put("keyA3", 3, "value3"))
+	value, _ = inverse.GetByKeyA(3)
+	assert.Equal(t, "value3", value)
+
+	require.NoError(t, inverse.RemoveByKeyA(2))
+	require.NoError(t, inverse.RemoveByKeyB("keyA3"))
+	assert.Equal(t, 1, bm.Size())
+	assert.Equal(t, 1, inverse.Size())
+	assert.False(t, inverse.Empty())
+	assert.Equal(t, []string{"value1"}, inverse.Values())
+	assert.Equal(t, "Inverse of BiKeyMap: map[keyA1:value1]", inverse.String())
+
+	inverse.Clear()
+	assert.True(t, bm.Empty())
+}
+
+func TestInverse_Rekey(t *testing.T) {
+	bm := New[string, int, string]()
+	require.NoError(t, bm.Put("keyA1", 1, "value1"))
+	inverse := bm.Inverse()
+
+	require.NoError(t, inverse.RekeyA(1, 2))
+	require.NoError(t, inverse.RekeyB("keyA1", "keyA2"))
+	require.ErrorIs(t, inverse.RekeyA(1, 3), ErrKeyBNotFound)
+
+	keyB, exists := bm.KeyBForKeyA("keyA2")
+	assert.True(t, exists)
+	assert.Equal(t, 2, keyB)
+}
+
+func TestInverse_PutVariants(t *testing.T) {
+	bm := New[string, int, string]()
+	inverse := bm.Inverse()
+
+	assert.True(t, inverse.PutIfAbsent(1, "keyA1", "value1"))
+	assert.False(t, inverse.PutIfAbsent(1, "keyA2", "value2"))
+	assert.True(t, inverse.Replace(1, "keyA1", "value10"))
+	assert.False(t, inverse.Replace(1, "keyA2", "value2"))
+	assert.Empty(t, inverse.ForcePut(1, "keyA1", "value11"))
+
+	evicted := inverse.ForcePut(1, "keyA2", "value2")
+	assert.Equal(t, []Entry[int, string, string]{{KeyA: 1, KeyB: "keyA1", Value: "value11"}}, evicted)
+	keyB, _ := bm.KeyBForKeyA("keyA2")
+	assert.Equal(t, 1, keyB)
+}
@@ -0,0 +1,97 @@
+package bikeymap
+
+import (
+	"fmt"
+)
+
+// MergeStrategy decides how Merge handles conflicts between the destination and the source map.
+type MergeStrategy int
+
+const (
+	// KeepExisting keeps the entry of the destination map.
+	KeepExisting MergeStrategy = iota
+	// Overwrite replaces the entry of the destination map with the one of the source map.
+	// Entries of the destination map which share only one key with the source entry are removed.
+	Overwrite
+	// ErrorOnConflict aborts the merge with ErrMergeConflict.
+	// If an entry of the source map conflicts with the key uniqueness,
+	// the error also wraps a *ConflictError.
+	ErrorOnConflict
+)
+
+// MergePolicy configures how Merge handles conflicts.
+type MergePolicy[KeyA comparable, KeyB comparable, V any] struct {
+	// Strategy handles key pairs which exist in both maps
+	// and entries whose keyA or keyB is paired with a different key in the destination map.
+	Strategy MergeStrategy
+	// Resolve, if set, replaces Strategy for key pairs which exist in both maps.
+	// It returns the merged value or an error which aborts the merge.
+	// Conflicts of the key uniqueness are still handled by Strategy.
+	Resolve func(keyA KeyA, keyB KeyB, existing V, incoming V) (V, error)
+}
+
+// Merge copies all entries of src into dst.
+// Conflicts are handled according to the policy.
+// If an error is returned, dst is left unchanged.
+func Merge[KeyA comparable, KeyB comparable, V any](dst, src *BiKeyMap[KeyA, KeyB, V], policy MergePolicy[KeyA, KeyB, V]) error {
+	entries := make([]Entry[KeyA, KeyB, V], 0, len(src.dataByKeyA))
+	for keyA, incoming := range src.dataByKeyA {
+		keyB := src.keyBByKeyA[keyA]
+		entry, ok, err := mergeEntry(dst, keyA, keyB, incoming, policy)
+		if err != nil {
+			return err
+		}
+		if ok {
+			entries = append(entries, entry)
+		}
+	}
+
+	for _, entry := range entries {
+		// Evict the pairs which share only one key with the entry, this is only needed for Overwrite.
+		if keyB, exists := dst.keyBByKeyA[entry.KeyA]; exists && keyB != entry.KeyB {
+			_ = dst.RemoveByKeyA(entry.KeyA)
+		}
+		if keyA, exists := dst.keyAByKeyB[entry.KeyB]; exists && keyA != entry.KeyA {
+			_ = dst.RemoveByKeyB(entry.KeyB)
+		}
+		_ = dst.Put(entry.KeyA, entry.KeyB, entry.Value)
+	}
+	return nil
+}
+
+// mergeEntry decides if and with which value an entry of the source map is put into dst.
+func mergeEntry[KeyA comparable, KeyB comparable, V any](
+	dst *BiKeyMap[KeyA, KeyB, V], keyA KeyA, keyB KeyB, incoming V, policy MergePolicy[KeyA, KeyB, V],
+) (Entry[KeyA, KeyB, V], bool, error) {
+	entry := Entry[KeyA, KeyB, V]{KeyA: keyA, KeyB: keyB, Value: incoming}
+	existingKeyB, keyAExists := dst.keyBByKeyA[keyA]
+	existingKeyA, keyBExists := dst.keyAByKeyB[keyB]
+
+	switch {
+	case !keyAExists && !keyBExists:
+		return entry, true, nil
+	case keyAExists && existingKeyB == keyB && policy.Resolve != nil:
+		resolved, err := policy.Resolve(keyA, keyB, dst.dataByKeyA[keyA], incoming)
+		if err != nil {
+			return entry, false, err
+		}
+		entry.Value = resolved
+		return entry, true, nil
+	}
+
+	switch policy.Strategy {
+	case KeepExisting:
+		return entry, false, nil
+	case Overwrite:
+		return entry, true, nil
+	case ErrorOnConflict:
+		if keyAExists && existingKeyB == keyB {
+			return entry, false, fmt.Errorf("%w: keyA %v and keyB %v exist in both maps", ErrMergeConflict, keyA, keyB)
+		}
+		if keyAExists {
+			return entry, false, fmt.Errorf("%w: %w", ErrMergeConflict, newKeyAConflictError(keyA, keyB, existingKeyB))
+		}
+		return entry, false, fmt.Errorf("%w: %w", ErrMergeConflict, newKeyBConflictError(keyA, keyB, existingKeyA))
+	}
+	return entry, false, nil
+}
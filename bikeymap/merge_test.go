@@ -0,0 +1,99 @@
+package bikeymap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMergeMaps(t *testing.T) (*BiKeyMap[string, int, string], *BiKeyMap[string, int, string]) {
+	t.Helper()
+	dst := New[string, int, string]()
+	require.NoError(t, dst.Put("keyA1", 1, "value1"))
+	require.NoError(t, dst.Put("keyA2", 2, "value2"))
+	require.NoError(t, dst.Put("keyA3", 3, "value3"))
+
+	src := New[string, int, string]()
+	// Same pair with a different value.
+	require.NoError(t, src.Put("keyA1", 1, "value10"))
+	// keyA2 is paired with a different keyB.
+	require.NoError(t, src.Put("keyA2", 20, "value20"))
+	// keyB 3 is paired with a different keyA.
+	require.NoError(t, src.Put("keyA30", 3, "value30"))
+	require.NoError(t, src.Put("keyA4", 4, "value4"))
+	return dst, src
+}
+
+func TestMerge_KeepExisting(t *testing.T) {
+	dst, src := newMergeMaps(t)
+	err := Merge(dst, src, MergePolicy[string, int, string]{Strategy: KeepExisting})
+	require.NoError(t, err)
+
+	expected := New[string, int, string]()
+	require.NoError(t, expected.Put("keyA1", 1, "value1"))
+	require.NoError(t, expected.Put("keyA2", 2, "value2"))
+	require.NoError(t, expected.Put("keyA3", 3, "value3"))
+	require.NoError(t, expected.Put("keyA4", 4, "value4"))
+	assert.True(t, Equal(expected, dst, stringEqual), dst.String())
+}
+
+func TestMerge_Overwrite(t *testing.T) {
+	dst, src := newMergeMaps(t)
+	err := Merge(dst, src, MergePolicy[string, int, string]{Strategy: Overwrite})
+	require.NoError(t, err)
+
+	assert.True(t, Equal(src, dst, stringEqual), dst.String())
+}
+
+func TestMerge_ErrorOnConflict(t *testing.T) {
+	dst, src := newMergeMaps(t)
+	err := Merge(dst, src, MergePolicy[string, int, string]{Strategy: ErrorOnConflict})
+	require.ErrorIs(t, err, ErrMergeConflict)
+
+	// The destination must be unchanged.
+	assert.Equal(t, 3, dst.Size())
+	_, exists := dst.GetByKeyA("keyA4")
+	assert.False(t, exists)
+
+	// A key which is paired differently in both maps is a key conflict as well.
+	src = New[string, int, string]()
+	require.NoError(t, src.Put("keyA1", 5, "value5"))
+	err = Merge(dst, src, MergePolicy[string, int, string]{Strategy: ErrorOnConflict})
+	require.ErrorIs(t, err, ErrMergeConflict)
+	require.ErrorIs(t, err, ErrKeyAConflict)
+
+	var conflictErr *ConflictError[string, int]
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, 5, conflictErr.KeyB)
+}
+
+func TestMerge_Resolve(t *testing.T) {
+	dst, src := newMergeMaps(t)
+	err := Merge(dst, src, MergePolicy[string, int, string]{
+		Strategy: KeepExisting,
+		Resolve: func(_ string, _ int, existing string, incoming string) (string, error) {
+			return existing + "+" + incoming, nil
+		},
+	})
+	require.NoError(t, err)
+
+	value, _ := dst.GetByKeyA("keyA1")
+	assert.Equal(t, "value1+value10", value)
+	value, _ = dst.GetByKeyA("keyA2")
+	assert.Equal(t, "value2", value)
+}
+
+func TestMerge_ResolveError(t *testing.T) {
+	dst, src := newMergeMaps(t)
+	resolveErr := errors.New("cannot resolve")
+	err := Merge(dst, src, MergePolicy[string, int, string]{
+		Resolve: func(_ string, _ int, _ string, _ string) (string, error) {
+			return "", resolveErr
+		},
+	})
+	require.ErrorIs(t, err, resolveErr)
+	_, exists := dst.GetByKeyA("keyA4")
+	assert.False(t, exists)
+}
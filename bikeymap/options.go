@@ -0,0 +1,39 @@
+package bikeymap
+
+// Option configures a BiKeyMap when it is created.
+type Option func(*options)
+
+type options struct {
+	insertionOrder bool
+	bloomFilter    bool
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithInsertionOrder keeps the entries in the order in which their keyA was first put, like a linked hash map.
+// KeysA, KeysB, Values, All and String return the entries in this order
+// and MoveToFront and MoveToBack change the position of an entry.
+// Putting, removing and moving an entry stays amortized O(1).
+func WithInsertionOrder() Option {
+	return func(o *options) {
+		o.insertionOrder = true
+	}
+}
+
+// WithBloomFilter keeps a bloom filter for each of the two keys,
+// which lets the lookups by keyA or keyB reject most missing keys without probing the maps.
+// Only keys whose underlying type is a string or an integer are filtered, other keys are looked up as usual.
+// The filters are updated by putting and removing entries
+// and are rebuilt when they are full or many of their keys were removed.
+// They need a few bytes per entry and make putting entries slower.
+func WithBloomFilter() Option {
+	return func(o *options) {
+		o.bloomFilter = true
+	}
+}
@@ -0,0 +1,257 @@
+package bikeymap
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+
+	"github.com/aeimer/go-multikeymap/internal/sortedset"
+)
+
+// OrderedBiKeyMap is the same as BiKeyMap, but it iterates in the order of the first keys.
+// KeysA, Values, All and String are ordered as well, so their output is deterministic.
+// Lookups are O(1) like BiKeyMap, putting and removing an entry takes O(log n) additionally.
+// The map must not be modified while iterating over it.
+// OrderedBiKeyMap is not safe for concurrent use.
+type OrderedBiKeyMap[KeyA cmp.Ordered, KeyB comparable, V any] struct {
+	keys sortedset.Set[KeyA]
+	base BiKeyMap[KeyA, KeyB, V]
+}
+
+// NewOrdered creates a new OrderedBiKeyMap instance.
+// The options are the same as for New, but WithInsertionOrder does not change the order of iteration.
+func NewOrdered[KeyA cmp.Ordered, KeyB comparable, V any](opts ...Option) *OrderedBiKeyMap[KeyA, KeyB, V] {
+	return &OrderedBiKeyMap[KeyA, KeyB, V]{
+		base: *New[KeyA, KeyB, V](opts...),
+	}
+}
+
+// Put stores a value with two keys. It only fails if one of the keys is already set without the other.
+// The returned error is a *ConflictError.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) Put(keyA KeyA, keyB KeyB, value V) error {
+	if err := m.base.Put(keyA, keyB, value); err != nil {
+		return err
+	}
+	m.keys.Add(keyA)
+	return nil
+}
+
+// ForcePut stores a value with two keys like Put, but never fails.
+// Entries whose keyA or keyB is paired with a different key are removed first and returned.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) ForcePut(keyA KeyA, keyB KeyB, value V) []Entry[KeyA, KeyB, V] {
+	evicted := m.base.ForcePut(keyA, keyB, value)
+	for _, entry := range evicted {
+		m.keys.Delete(entry.KeyA)
+	}
+	m.keys.Add(keyA)
+	return evicted
+}
+
+// PutIfAbsent stores a value with two keys only if neither of the keys is set.
+// It returns true if the value was stored.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) PutIfAbsent(keyA KeyA, keyB KeyB, value V) bool {
+	if !m.base.PutIfAbsent(keyA, keyB, value) {
+		return false
+	}
+	m.keys.Add(keyA)
+	return true
+}
+
+// Replace replaces the value of an existing entry only if keyA is paired with keyB.
+// It returns true if the value was replaced.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) Replace(keyA KeyA, keyB KeyB, value V) bool {
+	return m.base.Replace(keyA, keyB, value)
+}
+
+// RekeyA moves the entry of oldKeyA to newKeyA, keeping its keyB and value.
+// It returns ErrKeyANotFound if oldKeyA does not exist
+// and a *ConflictError matching ErrKeyAConflict if newKeyA is already set.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) RekeyA(oldKeyA KeyA, newKeyA KeyA) error {
+	if err := m.base.RekeyA(oldKeyA, newKeyA); err != nil {
+		return err
+	}
+	m.keys.Delete(oldKeyA)
+	m.keys.Add(newKeyA)
+	return nil
+}
+
+// RekeyB moves the entry of oldKeyB to newKeyB, keeping its keyA and value.
+// It returns ErrKeyBNotFound if oldKeyB does not exist
+// and a *ConflictError matching ErrKeyBConflict if newKeyB is already set.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) RekeyB(oldKeyB KeyB, newKeyB KeyB) error {
+	return m.base.RekeyB(oldKeyB, newKeyB)
+}
+
+// GetByKeyA retrieves a value using the first key.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) GetByKeyA(keyA KeyA) (V, bool) {
+	return m.base.GetByKeyA(keyA)
+}
+
+// GetByKeyB retrieves a value using the second key.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) GetByKeyB(keyB KeyB) (V, bool) {
+	return m.base.GetByKeyB(keyB)
+}
+
+// KeyBForKeyA returns the second key paired with the first key.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) KeyBForKeyA(keyA KeyA) (KeyB, bool) {
+	return m.base.KeyBForKeyA(keyA)
+}
+
+// KeyAForKeyB returns the first key paired with the second key.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) KeyAForKeyB(keyB KeyB) (KeyA, bool) {
+	return m.base.KeyAForKeyB(keyB)
+}
+
+// HasKeyA checks if the first key exists.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) HasKeyA(keyA KeyA) bool {
+	return m.base.HasKeyA(keyA)
+}
+
+// HasKeyB checks if the second key exists.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) HasKeyB(keyB KeyB) bool {
+	return m.base.HasKeyB(keyB)
+}
+
+// KeysA returns a slice of all first keys in the map in ascending order.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) KeysA() []KeyA {
+	keys := make([]KeyA, 0, m.keys.Len())
+	for keyA := range m.keys.Ascend() {
+		keys = append(keys, keyA)
+	}
+	return keys
+}
+
+// KeysB returns a slice of all second keys in the map in ascending order of their first keys.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) KeysB() []KeyB {
+	keys := make([]KeyB, 0, m.keys.Len())
+	for keyA := range m.keys.Ascend() {
+		keys = append(keys, m.base.keyBByKeyA[keyA])
+	}
+	return keys
+}
+
+// Inverse returns a view of the map with the roles of KeyA and KeyB swapped.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) Inverse() *Inverse[KeyB, KeyA, V] {
+	return &Inverse[KeyB, KeyA, V]{m: m}
+}
+
+// RemoveByKeyA removes a value using the first key, ensuring the corresponding second key is also deleted.
+// It returns ErrKeyANotFound if keyA does not exist.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) RemoveByKeyA(keyA KeyA) error {
+	if err := m.base.RemoveByKeyA(keyA); err != nil {
+		return err
+	}
+	m.keys.Delete(keyA)
+	return nil
+}
+
+// RemoveByKeyB removes a value using the second key, ensuring the corresponding first key is also deleted.
+// It returns ErrKeyBNotFound if keyB does not exist.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) RemoveByKeyB(keyB KeyB) error {
+	keyA, exists := m.base.KeyAForKeyB(keyB)
+	if !exists {
+		return ErrKeyBNotFound
+	}
+	return m.RemoveByKeyA(keyA)
+}
+
+// Ascend returns an iterator over all first keys and their values in ascending order of the first keys.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) Ascend() iter.Seq2[KeyA, V] {
+	return m.entries(m.keys.Ascend())
+}
+
+// Descend returns an iterator over all first keys and their values in descending order of the first keys.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) Descend() iter.Seq2[KeyA, V] {
+	return m.entries(m.keys.Descend())
+}
+
+// AscendRange returns an iterator over the first keys in the range [greaterOrEqual, lessThan) and their values
+// in ascending order of the first keys.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) AscendRange(greaterOrEqual KeyA, lessThan KeyA) iter.Seq2[KeyA, V] {
+	return m.entries(m.keys.AscendRange(greaterOrEqual, lessThan))
+}
+
+// entries returns an iterator over the first keys and their values.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) entries(keys iter.Seq[KeyA]) iter.Seq2[KeyA, V] {
+	return func(yield func(KeyA, V) bool) {
+		for keyA := range keys {
+			if !yield(keyA, m.base.dataByKeyA[keyA]) {
+				return
+			}
+		}
+	}
+}
+
+// First returns the entry with the smallest first key. It returns false if the map is empty.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) First() (Entry[KeyA, KeyB, V], bool) {
+	keyA, exists := m.keys.Min()
+	return m.entry(keyA), exists
+}
+
+// Last returns the entry with the largest first key. It returns false if the map is empty.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) Last() (Entry[KeyA, KeyB, V], bool) {
+	keyA, exists := m.keys.Max()
+	return m.entry(keyA), exists
+}
+
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) entry(keyA KeyA) Entry[KeyA, KeyB, V] {
+	keyB, exists := m.base.keyBByKeyA[keyA]
+	if !exists {
+		return Entry[KeyA, KeyB, V]{}
+	}
+	return Entry[KeyA, KeyB, V]{KeyA: keyA, KeyB: keyB, Value: m.base.dataByKeyA[keyA]}
+}
+
+// Keys returns a slice of all first keys in the map in ascending order. It is the same as KeysA.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) Keys() []KeyA {
+	return m.KeysA()
+}
+
+// Has checks if the first key exists. It is the same as HasKeyA.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) Has(keyA KeyA) bool {
+	return m.base.Has(keyA)
+}
+
+// Get retrieves a value using the first key. It is the same as GetByKeyA.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) Get(keyA KeyA) (V, bool) {
+	return m.base.Get(keyA)
+}
+
+// All returns an iterator over all first keys and their values in ascending order. It is the same as Ascend.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) All() iter.Seq2[KeyA, V] {
+	return m.Ascend()
+}
+
+// Empty checks if the map is empty.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) Empty() bool {
+	return m.base.Empty()
+}
+
+// Size returns the number of elements in the map.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) Size() int {
+	return m.base.Size()
+}
+
+// Values returns a slice of all values in the map in ascending order of the first keys.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) Values() []V {
+	values := make([]V, 0, m.keys.Len())
+	for _, value := range m.Ascend() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Clear removes all elements from the map.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) Clear() {
+	m.base.Clear()
+	m.keys.Clear()
+}
+
+// String returns a string representation of the map in ascending order of the first keys.
+func (m *OrderedBiKeyMap[KeyA, KeyB, V]) String() string {
+	entries := make([]Entry[KeyA, KeyB, V], 0, m.keys.Len())
+	for keyA := range m.keys.Ascend() {
+		entries = append(entries, m.entry(keyA))
+	}
+	return fmt.Sprintf("OrderedBiKeyMap: %v", entries)
+}
@@ -0,0 +1,106 @@
+package bikeymap
+
+import (
+	"fmt"
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleNewOrdered() {
+	bm := NewOrdered[string, int, string]()
+	_ = bm.Put("Hamburg", 20095, "HH")
+	_ = bm.Put("Berlin", 10115, "BE")
+	for city, state := range bm.Ascend() {
+		fmt.Printf("%s: %s\n", city, state)
+	}
+	fmt.Println(bm)
+
+	// Output:
+	// Berlin: BE
+	// Hamburg: HH
+	// OrderedBiKeyMap: [{Berlin 10115 BE} {Hamburg 20095 HH}]
+}
+
+// orderedKeys collects the keys of an iterator in their order.
+func orderedKeys[K any, V any](seq iter.Seq2[K, V]) []K {
+	var keys []K
+	for key := range seq {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestOrderedBiKeyMap_Ascend(t *testing.T) {
+	bm := NewOrdered[int, string, string]()
+	for _, keyA := range []int{5, 1, 4, 2, 3} {
+		require.NoError(t, bm.Put(keyA, fmt.Sprint("b", keyA), fmt.Sprint("value", keyA)))
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, orderedKeys(bm.Ascend()))
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, orderedKeys(bm.Descend()))
+	assert.Equal(t, []int{2, 3}, orderedKeys(bm.AscendRange(2, 4)))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, orderedKeys(bm.All()))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, bm.Keys())
+	assert.Equal(t, []string{"b1", "b2", "b3", "b4", "b5"}, bm.KeysB())
+	assert.Equal(t, []string{"value1", "value2", "value3", "value4", "value5"}, bm.Values())
+
+	first, exists := bm.First()
+	assert.True(t, exists)
+	assert.Equal(t, Entry[int, string, string]{KeyA: 1, KeyB: "b1", Value: "value1"}, first)
+	last, exists := bm.Last()
+	assert.True(t, exists)
+	assert.Equal(t, Entry[int, string, string]{KeyA: 5, KeyB: "b5", Value: "value5"}, last)
+}
+
+func TestOrderedBiKeyMap_Modify(t *testing.T) {
+	bm := NewOrdered[int, string, string]()
+	require.NoError(t, bm.Put(1, "a", "value1"))
+	require.NoError(t, bm.Put(2, "b", "value2"))
+	require.ErrorIs(t, bm.Put(3, "a", "value3"), ErrKeyBConflict)
+	assert.False(t, bm.PutIfAbsent(3, "b", "value3"))
+	assert.True(t, bm.PutIfAbsent(3, "c", "value3"))
+	assert.Equal(t, []int{1, 2, 3}, bm.KeysA())
+
+	evicted := bm.ForcePut(4, "a", "value4")
+	assert.Equal(t, []Entry[int, string, string]{{KeyA: 1, KeyB: "a", Value: "value1"}}, evicted)
+	assert.Equal(t, []int{2, 3, 4}, bm.KeysA())
+
+	require.NoError(t, bm.RekeyA(4, 0))
+	require.ErrorIs(t, bm.RekeyA(4, 5), ErrKeyANotFound)
+	assert.Equal(t, []int{0, 2, 3}, bm.KeysA())
+
+	require.NoError(t, bm.RemoveByKeyB("b"))
+	require.ErrorIs(t, bm.RemoveByKeyB("b"), ErrKeyBNotFound)
+	require.NoError(t, bm.RemoveByKeyA(3))
+	require.ErrorIs(t, bm.RemoveByKeyA(3), ErrKeyANotFound)
+	assert.Equal(t, []int{0}, bm.KeysA())
+
+	inverse := bm.Inverse()
+	require.NoError(t, inverse.Put("d", 7, "value7"))
+	assert.Equal(t, []int{0, 7}, bm.KeysA())
+
+	bm.Clear()
+	assert.Empty(t, bm.KeysA())
+	_, exists := bm.First()
+	assert.False(t, exists)
+	_, exists = bm.Last()
+	assert.False(t, exists)
+}
+
+func TestOrderedBiKeyMap_Options(t *testing.T) {
+	bm := NewOrdered[int, string, string](WithBloomFilter(), WithInsertionOrder())
+	assert.NotNil(t, bm.base.filterA)
+	assert.NotNil(t, bm.base.filterB)
+	for _, keyA := range []int{3, 1, 2} {
+		require.NoError(t, bm.Put(keyA, fmt.Sprint("b", keyA), fmt.Sprint("value", keyA)))
+	}
+
+	value, found := bm.GetByKeyB("b2")
+	assert.True(t, found)
+	assert.Equal(t, "value2", value)
+	assert.False(t, bm.HasKeyA(4))
+	assert.Equal(t, []int{1, 2, 3}, orderedKeys(bm.Ascend()))
+}
@@ -0,0 +1,153 @@
+package bimultimap
+
+import (
+	"fmt"
+)
+
+// Pair is a single relation between an A and a B.
+type Pair[A comparable, B comparable] struct {
+	A A
+	B B
+}
+
+// BiMultiMap is a generic in-memory many-to-many relation between As and Bs, for example users and groups.
+// Both directions are indexed, so all lookups are O(1).
+// It implements container/Container with the relations as values.
+type BiMultiMap[A comparable, B comparable] struct {
+	bsByA map[A]map[B]struct{}
+	asByB map[B]map[A]struct{}
+	size  int
+}
+
+// New creates a new instance of BiMultiMap.
+func New[A comparable, B comparable]() *BiMultiMap[A, B] {
+	return &BiMultiMap[A, B]{
+		bsByA: make(map[A]map[B]struct{}),
+		asByB: make(map[B]map[A]struct{}),
+	}
+}
+
+// Add relates a with b. Adding an existing relation has no effect.
+func (m *BiMultiMap[A, B]) Add(a A, b B) {
+	if _, exists := m.bsByA[a][b]; exists {
+		return
+	}
+	if m.bsByA[a] == nil {
+		m.bsByA[a] = make(map[B]struct{})
+	}
+	if m.asByB[b] == nil {
+		m.asByB[b] = make(map[A]struct{})
+	}
+	m.bsByA[a][b] = struct{}{}
+	m.asByB[b][a] = struct{}{}
+	m.size++
+}
+
+// Remove removes the relation between a and b.
+func (m *BiMultiMap[A, B]) Remove(a A, b B) {
+	if _, exists := m.bsByA[a][b]; !exists {
+		return
+	}
+	delete(m.bsByA[a], b)
+	if len(m.bsByA[a]) == 0 {
+		delete(m.bsByA, a)
+	}
+	delete(m.asByB[b], a)
+	if len(m.asByB[b]) == 0 {
+		delete(m.asByB, b)
+	}
+	m.size--
+}
+
+// RemoveA removes all relations of a.
+func (m *BiMultiMap[A, B]) RemoveA(a A) {
+	for b := range m.bsByA[a] {
+		delete(m.asByB[b], a)
+		if len(m.asByB[b]) == 0 {
+			delete(m.asByB, b)
+		}
+	}
+	m.size -= len(m.bsByA[a])
+	delete(m.bsByA, a)
+}
+
+// RemoveB removes all relations of b.
+func (m *BiMultiMap[A, B]) RemoveB(b B) {
+	for a := range m.asByB[b] {
+		delete(m.bsByA[a], b)
+		if len(m.bsByA[a]) == 0 {
+			delete(m.bsByA, a)
+		}
+	}
+	m.size -= len(m.asByB[b])
+	delete(m.asByB, b)
+}
+
+// Has checks if a is related with b.
+func (m *BiMultiMap[A, B]) Has(a A, b B) bool {
+	_, exists := m.bsByA[a][b]
+	return exists
+}
+
+// HasA checks if a has at least one relation.
+func (m *BiMultiMap[A, B]) HasA(a A) bool {
+	_, exists := m.bsByA[a]
+	return exists
+}
+
+// HasB checks if b has at least one relation.
+func (m *BiMultiMap[A, B]) HasB(b B) bool {
+	_, exists := m.asByB[b]
+	return exists
+}
+
+// BsForA returns all Bs related with a.
+func (m *BiMultiMap[A, B]) BsForA(a A) []B {
+	bs := make([]B, 0, len(m.bsByA[a]))
+	for b := range m.bsByA[a] {
+		bs = append(bs, b)
+	}
+	return bs
+}
+
+// AsForB returns all As related with b.
+func (m *BiMultiMap[A, B]) AsForB(b B) []A {
+	as := make([]A, 0, len(m.asByB[b]))
+	for a := range m.asByB[b] {
+		as = append(as, a)
+	}
+	return as
+}
+
+// Empty checks if the map contains no relations.
+func (m *BiMultiMap[A, B]) Empty() bool {
+	return m.size == 0
+}
+
+// Size returns the number of relations in the map.
+func (m *BiMultiMap[A, B]) Size() int {
+	return m.size
+}
+
+// Values returns a slice of all relations in the map.
+func (m *BiMultiMap[A, B]) Values() []Pair[A, B] {
+	values := make([]Pair[A, B], 0, m.size)
+	for a, bs := range m.bsByA {
+		for b := range bs {
+			values = append(values, Pair[A, B]{A: a, B: b})
+		}
+	}
+	return values
+}
+
+// Clear removes all relations from the map.
+func (m *BiMultiMap[A, B]) Clear() {
+	m.bsByA = make(map[A]map[B]struct{})
+	m.asByB = make(map[B]map[A]struct{})
+	m.size = 0
+}
+
+// String returns a string representation of the map.
+func (m *BiMultiMap[A, B]) String() string {
+	return fmt.Sprintf("BiMultiMap: %v", m.bsByA)
+}
@@ -0,0 +1,169 @@
+package bimultimap
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleNew() {
+	bm := New[string, string]()
+	bm.Add("alice", "admins")
+	bm.Add("alice", "users")
+	bm.Add("bob", "users")
+	groups := bm.BsForA("alice")
+	slices.Sort(groups)
+	fmt.Printf("[alice] groups: %v\n", groups)
+	members := bm.AsForB("users")
+	slices.Sort(members)
+	fmt.Printf("[users] members: %v\n", members)
+	// Output:
+	// [alice] groups: [admins users]
+	// [users] members: [alice bob]
+}
+
+func TestBiMultiMap_ImplementsContainerInterface(t *testing.T) {
+	instance := New[int, int]()
+	if _, ok := any(instance).(container.Container[Pair[int, int]]); !ok {
+		t.Error("BiMultiMap does not implement the Container interface")
+	}
+}
+
+func TestBiMultiMap_Add(t *testing.T) {
+	bm := New[string, int]()
+	bm.Add("a1", 1)
+	bm.Add("a1", 2)
+	bm.Add("a2", 1)
+	bm.Add("a2", 1)
+
+	assert.ElementsMatch(t, []int{1, 2}, bm.BsForA("a1"))
+	assert.ElementsMatch(t, []int{1}, bm.BsForA("a2"))
+	assert.ElementsMatch(t, []string{"a1", "a2"}, bm.AsForB(1))
+	assert.ElementsMatch(t, []string{"a1"}, bm.AsForB(2))
+	assert.True(t, bm.Has("a2", 1))
+	assert.False(t, bm.Has("a2", 2))
+	assert.Equal(t, 3, bm.Size())
+}
+
+func TestBiMultiMap_Remove(t *testing.T) {
+	bm := New[string, int]()
+	bm.Add("a1", 1)
+	bm.Add("a1", 2)
+	bm.Add("a2", 1)
+
+	bm.Remove("a1", 1)
+	bm.Remove("a1", 1)
+	bm.Remove("a3", 3)
+	assert.False(t, bm.Has("a1", 1))
+	assert.ElementsMatch(t, []int{2}, bm.BsForA("a1"))
+	assert.ElementsMatch(t, []string{"a2"}, bm.AsForB(1))
+	assert.Equal(t, 2, bm.Size())
+
+	bm.Remove("a1", 2)
+	assert.False(t, bm.HasA("a1"))
+	assert.False(t, bm.HasB(2))
+	assert.Empty(t, bm.BsForA("a1"))
+}
+
+func TestBiMultiMap_RemoveA(t *testing.T) {
+	bm := New[string, int]()
+	bm.Add("a1", 1)
+	bm.Add("a1", 2)
+	bm.Add("a2", 1)
+
+	bm.RemoveA("a1")
+	bm.RemoveA("a3")
+	assert.False(t, bm.HasA("a1"))
+	assert.False(t, bm.HasB(2))
+	assert.ElementsMatch(t, []string{"a2"}, bm.AsForB(1))
+	assert.Equal(t, 1, bm.Size())
+}
+
+func TestBiMultiMap_RemoveB(t *testing.T) {
+	bm := New[string, int]()
+	bm.Add("a1", 1)
+	bm.Add("a1", 2)
+	bm.Add("a2", 1)
+
+	bm.RemoveB(1)
+	bm.RemoveB(3)
+	assert.False(t, bm.HasB(1))
+	assert.False(t, bm.HasA("a2"))
+	assert.ElementsMatch(t, []int{2}, bm.BsForA("a1"))
+	assert.Equal(t, 1, bm.Size())
+}
+
+func TestBiMultiMap_String(t *testing.T) {
+	bm := New[string, int]()
+	bm.Add("a1", 1)
+	assert.Equal(t, "BiMultiMap: map[a1:map[1:{}]]", bm.String())
+}
+
+func TestBiMultiMap_EmptyAndSize(t *testing.T) {
+	bm := New[string, int]()
+	assert.True(t, bm.Empty())
+	assert.Equal(t, 0, bm.Size())
+
+	bm.Add("a1", 1)
+	assert.False(t, bm.Empty())
+	assert.Equal(t, 1, bm.Size())
+}
+
+func TestBiMultiMap_ValuesAndClear(t *testing.T) {
+	bm := New[string, int]()
+	bm.Add("a1", 1)
+	bm.Add("a1", 2)
+	bm.Add("a2", 1)
+	assert.ElementsMatch(t, []Pair[string, int]{{"a1", 1}, {"a1", 2}, {"a2", 1}}, bm.Values())
+
+	bm.Clear()
+	assert.True(t, bm.Empty())
+	assert.Empty(t, bm.Values())
+	assert.False(t, bm.HasB(1))
+}
+
+// Benchmarks
+
+var benchmarkSizes = []struct {
+	size int
+}{
+	{size: 100},
+	{size: 1000},
+	{size: 10_000},
+	{size: 100_000},
+}
+
+func BenchmarkBiMultiMapAdd(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size_%d", v.size), func(b *testing.B) {
+			m := New[string, int]()
+			b.ResetTimer()
+			for range b.N {
+				for n := range v.size {
+					m.Add(strconv.Itoa(n), n%10)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBiMultiMapBsForA(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size_%d", v.size), func(b *testing.B) {
+			m := New[string, int]()
+			for n := range v.size {
+				m.Add(strconv.Itoa(n), n%10)
+			}
+			b.ResetTimer()
+			for range b.N {
+				for n := range v.size {
+					m.BsForA(strconv.Itoa(n))
+				}
+			}
+		})
+	}
+}
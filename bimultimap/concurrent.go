@@ -0,0 +1,120 @@
+package bimultimap
+
+import (
+	"fmt"
+	"sync"
+)
+
+// ConcurrentBiMultiMap is the same as BiMultiMap, but it is safe for concurrent use.
+// It uses a RWMutex to protect the map from concurrent reads and writes.
+// Therefore, it is slower than BiMultiMap, but it is safe for concurrent use.
+// The wrapped BiMultiMap is not exposed, so it can only be accessed while holding the lock.
+type ConcurrentBiMultiMap[A comparable, B comparable] struct {
+	mu   sync.RWMutex
+	base BiMultiMap[A, B]
+}
+
+// NewConcurrent creates a new instance of ConcurrentBiMultiMap.
+func NewConcurrent[A comparable, B comparable]() *ConcurrentBiMultiMap[A, B] {
+	return &ConcurrentBiMultiMap[A, B]{
+		base: *New[A, B](),
+	}
+}
+
+// Add relates a with b. Adding an existing relation has no effect.
+func (m *ConcurrentBiMultiMap[A, B]) Add(a A, b B) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.base.Add(a, b)
+}
+
+// Remove removes the relation between a and b.
+func (m *ConcurrentBiMultiMap[A, B]) Remove(a A, b B) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.base.Remove(a, b)
+}
+
+// RemoveA removes all relations of a.
+func (m *ConcurrentBiMultiMap[A, B]) RemoveA(a A) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.base.RemoveA(a)
+}
+
+// RemoveB removes all relations of b.
+func (m *ConcurrentBiMultiMap[A, B]) RemoveB(b B) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.base.RemoveB(b)
+}
+
+// Has checks if a is related with b.
+func (m *ConcurrentBiMultiMap[A, B]) Has(a A, b B) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.Has(a, b)
+}
+
+// HasA checks if a has at least one relation.
+func (m *ConcurrentBiMultiMap[A, B]) HasA(a A) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.HasA(a)
+}
+
+// HasB checks if b has at least one relation.
+func (m *ConcurrentBiMultiMap[A, B]) HasB(b B) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.HasB(b)
+}
+
+// BsForA returns all Bs related with a.
+func (m *ConcurrentBiMultiMap[A, B]) BsForA(a A) []B {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.BsForA(a)
+}
+
+// AsForB returns all As related with b.
+func (m *ConcurrentBiMultiMap[A, B]) AsForB(b B) []A {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.AsForB(b)
+}
+
+// Empty checks if the map contains no relations.
+func (m *ConcurrentBiMultiMap[A, B]) Empty() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.Empty()
+}
+
+// Size returns the number of relations in the map.
+func (m *ConcurrentBiMultiMap[A, B]) Size() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.Size()
+}
+
+// Values returns a slice of all relations in the map.
+func (m *ConcurrentBiMultiMap[A, B]) Values() []Pair[A, B] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.Values()
+}
+
+// Clear removes all relations from the map.
+func (m *ConcurrentBiMultiMap[A, B]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.base.Clear()
+}
+
+// String returns a string representation of the map.
+func (m *ConcurrentBiMultiMap[A, B]) String() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return fmt.Sprintf("ConcurrentBiMultiMap: %v", m.base.bsByA)
+}
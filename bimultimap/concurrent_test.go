@@ -0,0 +1,100 @@
+package bimultimap
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/container"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleNewConcurrent() {
+	bm := NewConcurrent[string, string]()
+	bm.Add("alice", "admins")
+	fmt.Printf("[admins] members: %v\n", bm.AsForB("admins"))
+	// Output:
+	// [admins] members: [alice]
+}
+
+func TestConcurrentBiMultiMap_ImplementsContainerInterface(t *testing.T) {
+	instance := NewConcurrent[int, int]()
+	if _, ok := any(instance).(container.Container[Pair[int, int]]); !ok {
+		t.Error("ConcurrentBiMultiMap does not implement the Container interface")
+	}
+}
+
+func TestConcurrentBiMultiMap_HidesUnderlyingMap(t *testing.T) {
+	concurrentType := reflect.TypeFor[ConcurrentBiMultiMap[string, int]]()
+	for i := range concurrentType.NumField() {
+		field := concurrentType.Field(i)
+		assert.False(t, field.IsExported(), "field %s allows access without locking", field.Name)
+	}
+
+	// Every method of BiMultiMap must be implemented with locking, as nothing is promoted.
+	pointerType := reflect.PointerTo(concurrentType)
+	baseType := reflect.TypeFor[*BiMultiMap[string, int]]()
+	for i := range baseType.NumMethod() {
+		method := baseType.Method(i)
+		_, exists := pointerType.MethodByName(method.Name)
+		assert.True(t, exists, "method %s is missing", method.Name)
+	}
+}
+
+func TestConcurrentBiMultiMap_AddAndRemove(t *testing.T) {
+	bm := NewConcurrent[string, int]()
+	bm.Add("a1", 1)
+	bm.Add("a1", 2)
+	bm.Add("a2", 1)
+	bm.Add("a3", 3)
+	assert.True(t, bm.Has("a1", 2))
+	assert.True(t, bm.HasA("a2"))
+	assert.True(t, bm.HasB(3))
+	assert.ElementsMatch(t, []int{1, 2}, bm.BsForA("a1"))
+	assert.ElementsMatch(t, []string{"a1", "a2"}, bm.AsForB(1))
+	assert.Len(t, bm.Values(), 4)
+
+	bm.Remove("a1", 2)
+	bm.RemoveA("a2")
+	bm.RemoveB(3)
+	assert.Equal(t, 1, bm.Size())
+	bm.Clear()
+	assert.True(t, bm.Empty())
+}
+
+func TestConcurrentBiMultiMap_String(t *testing.T) {
+	bm := NewConcurrent[string, int]()
+	bm.Add("a1", 1)
+	assert.Equal(t, "ConcurrentBiMultiMap: map[a1:map[1:{}]]", bm.String())
+}
+
+func TestConcurrentBiMultiMap_ConcurrentAccess(t *testing.T) {
+	bm := NewConcurrent[int, int]()
+	var wg sync.WaitGroup
+	const numGoroutines = 100
+
+	for i := range numGoroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bm.Add(i, i%10)
+			bm.AsForB(i % 10)
+			bm.Size()
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, numGoroutines, bm.Size())
+
+	for i := range numGoroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			bm.RemoveA(i)
+			bm.BsForA(i)
+			bm.Empty()
+		}()
+	}
+	wg.Wait()
+	assert.True(t, bm.Empty())
+}
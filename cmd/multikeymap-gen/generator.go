@@ -0,0 +1,330 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+//go:embed templates/*.go.tmpl
+var templateFiles embed.FS
+
+var templates = template.Must(template.ParseFS(templateFiles, "templates/*.go.tmpl"))
+
+// config holds the options of the generator.
+type config struct {
+	typeName   string
+	primary    string
+	keys       []string
+	concurrent bool
+	name       string
+	args       []string // Command line arguments for the header of the generated file
+}
+
+// collectionName returns the name of the generated collection type.
+func (c config) collectionName() string {
+	if c.name != "" {
+		return c.name
+	}
+	if c.concurrent {
+		return "Concurrent" + c.typeName + "Collection"
+	}
+	return c.typeName + "Collection"
+}
+
+// fileName returns the default name of the generated file.
+func (c config) fileName() string {
+	name := snakeCase(c.typeName)
+	if c.concurrent {
+		name += "_concurrent"
+	}
+	return name + "_collection.go"
+}
+
+// field is a struct field used as key.
+type field struct {
+	Name   string
+	Method string // Name of the getter, e.g. GetByPostcode
+	Type   string
+	// Group is the group name of a MultiKeyMap collection.
+	Group string
+	// Format is the expression which formats the field of value as secondary key of a MultiKeyMap collection.
+	Format string
+	// FormatOld is the expression which formats the field of the replaced value old.
+	FormatOld string
+	// FormatKey is the expression which formats the parameter key as secondary key of a MultiKeyMap collection.
+	FormatKey string
+	// Var is the name of the local variable which holds the formatted secondary key.
+	Var string
+}
+
+// data is passed to the templates.
+type data struct {
+	Header     string
+	Package    string
+	Imports    string
+	Name       string
+	Type       string
+	Concurrent bool
+	Primary    field
+	Keys       []field
+	KeyNames   string // Names of the primary key and the key fields for the doc comment, e.g. "ID, Postcode and ISO"
+}
+
+// structType is a parsed struct type declaration.
+type structType struct {
+	pkg     string
+	fields  map[string]ast.Expr
+	imports []*ast.ImportSpec // Imports of the file which declares the type
+}
+
+// generate parses the package in dir and generates the collection for the configured type.
+func generate(dir string, cfg config) ([]byte, error) {
+	st, err := parseStructType(dir, cfg.typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	d := data{
+		Header:     strings.TrimSpace("multikeymap-gen " + strings.Join(cfg.args, " ")),
+		Package:    st.pkg,
+		Name:       cfg.collectionName(),
+		Type:       cfg.typeName,
+		Concurrent: cfg.concurrent,
+	}
+	usedPackages := make(map[string]bool)
+	d.Primary, err = st.field(cfg.primary, usedPackages)
+	if err != nil {
+		return nil, err
+	}
+	if len(cfg.keys) == 0 {
+		return nil, errors.New("at least one key field is required")
+	}
+	for _, name := range cfg.keys {
+		if name == cfg.primary || slices.ContainsFunc(d.Keys, func(f field) bool { return f.Name == name }) {
+			return nil, fmt.Errorf("field %s is used more than once", name)
+		}
+		key, err := st.field(name, usedPackages)
+		if err != nil {
+			return nil, err
+		}
+		d.Keys = append(d.Keys, key)
+	}
+
+	tmpl := "bikeymap.go.tmpl"
+	stdImports := []string{}
+	if len(d.Keys) > 1 {
+		tmpl = "multikeymap.go.tmpl"
+		for i := range d.Keys {
+			key := &d.Keys[i]
+			key.Group = key.Name
+			key.Var = lowerCamelCase(key.Name) + "Key"
+			if key.Format, err = formatExpr(*key, "value."+key.Name); err != nil {
+				return nil, err
+			}
+			key.FormatOld, _ = formatExpr(*key, "old."+key.Name)
+			key.FormatKey, _ = formatExpr(*key, "key")
+			if key.Format != "value."+key.Name {
+				stdImports = append(stdImports, strconv.Quote("strconv"))
+			}
+		}
+	}
+	if cfg.concurrent {
+		stdImports = append(stdImports, strconv.Quote("sync"))
+	}
+	d.Imports = importBlock(stdImports, st.usedImports(usedPackages), tmpl)
+	d.KeyNames = nameList(append([]field{d.Primary}, d.Keys...))
+
+	var buf bytes.Buffer
+	if err := templates.ExecuteTemplate(&buf, tmpl, d); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// parseStructType finds the declaration of the struct type in the non-test Go files of dir.
+func parseStructType(dir string, typeName string) (*structType, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		file, err := parser.ParseFile(fset, path, src, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if typeSpec.Name.Name != typeName {
+					continue
+				}
+				if typeSpec.TypeParams != nil {
+					return nil, fmt.Errorf("type %s is generic, which is not supported", typeName)
+				}
+				structAST, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					return nil, fmt.Errorf("type %s is not a struct", typeName)
+				}
+				st := &structType{pkg: file.Name.Name, fields: make(map[string]ast.Expr), imports: file.Imports}
+				for _, f := range structAST.Fields.List {
+					for _, name := range f.Names {
+						st.fields[name.Name] = f.Type
+					}
+				}
+				return st, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("type %s not found in %s", typeName, dir)
+}
+
+// field returns the key field with the given name and records the packages used by its type.
+func (st *structType) field(name string, usedPackages map[string]bool) (field, error) {
+	expr, exists := st.fields[name]
+	if !exists {
+		return field{}, fmt.Errorf("field %s not found", name)
+	}
+	ast.Inspect(expr, func(node ast.Node) bool {
+		if sel, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := sel.X.(*ast.Ident); ok {
+				usedPackages[ident.Name] = true
+			}
+		}
+		return true
+	})
+	return field{Name: name, Method: "GetBy" + upperFirst(name), Type: typeString(expr)}, nil
+}
+
+// usedImports returns the import specs of the packages used by the key fields.
+func (st *structType) usedImports(usedPackages map[string]bool) []string {
+	var imports []string
+	for _, spec := range st.imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		name := filepath.Base(path)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		if !usedPackages[name] {
+			continue
+		}
+		if spec.Name != nil {
+			imports = append(imports, spec.Name.Name+" "+spec.Path.Value)
+		} else {
+			imports = append(imports, spec.Path.Value)
+		}
+	}
+	return imports
+}
+
+// typeString returns the source code of a type expression.
+func typeString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	_ = format.Node(&buf, token.NewFileSet(), expr)
+	return buf.String()
+}
+
+// formatExpr returns the expression which formats the operand of the key field type as string.
+func formatExpr(key field, operand string) (string, error) {
+	switch key.Type {
+	case "string":
+		return operand, nil
+	case "int", "int8", "int16", "int32", "int64":
+		return fmt.Sprintf("strconv.FormatInt(int64(%s), 10)", operand), nil
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr":
+		return fmt.Sprintf("strconv.FormatUint(uint64(%s), 10)", operand), nil
+	default:
+		return "", fmt.Errorf("key field %s has type %s, but a string or an integer type is required with more than one key field",
+			key.Name, key.Type)
+	}
+}
+
+// importBlock returns the standard library imports and, separated by an empty line, the other imports.
+// The imports of the key fields are sorted into these groups like goimports does.
+func importBlock(stdImports []string, fieldImports []string, tmpl string) string {
+	otherImports := []string{strconv.Quote("github.com/aeimer/go-multikeymap/" + strings.TrimSuffix(tmpl, ".go.tmpl"))}
+	for _, spec := range fieldImports {
+		path := spec[strings.IndexByte(spec, '"')+1:]
+		if first, _, _ := strings.Cut(path, "/"); strings.Contains(first, ".") {
+			otherImports = append(otherImports, spec)
+		} else {
+			stdImports = append(stdImports, spec)
+		}
+	}
+	slices.Sort(stdImports)
+	groups := []string{strings.Join(slices.Compact(stdImports), "\n"), strings.Join(otherImports, "\n")}
+	return strings.Join(slices.DeleteFunc(groups, func(group string) bool { return group == "" }), "\n\n")
+}
+
+// nameList joins the names of the fields like "ID, Postcode and ISO".
+func nameList(fields []field) string {
+	names := make([]string, 0, len(fields))
+	for _, f := range fields {
+		names = append(names, f.Name)
+	}
+	last := len(names) - 1
+	return strings.Join(names[:last], ", ") + " and " + names[last]
+}
+
+// upperFirst converts the first letter of a name to upper case.
+func upperFirst(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// lowerCamelCase lowers the first word of an exported name, e.g. ISOCode becomes isoCode.
+func lowerCamelCase(name string) string {
+	runes := []rune(name)
+	for i := range runes {
+		if !unicode.IsUpper(runes[i]) {
+			if i > 1 {
+				i--
+			}
+			return strings.ToLower(string(runes[:i])) + string(runes[i:])
+		}
+	}
+	return strings.ToLower(name)
+}
+
+// snakeCase converts an exported name to snake case, e.g. ISOCode becomes iso_code.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) &&
+			(!unicode.IsUpper(runes[i-1]) || i+1 < len(runes) && !unicode.IsUpper(runes[i+1])) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files in internal/example")
+
+const exampleDir = "internal/example"
+
+// TestGenerate_Golden compares the output of the go:generate directives in internal/example
+// with the generated files, which are compiled and tested in that package.
+func TestGenerate_Golden(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join(exampleDir, "example.go"))
+	require.NoError(t, err)
+
+	const directive = "//go:generate go run github.com/aeimer/go-multikeymap/cmd/multikeymap-gen "
+	var found int
+	for _, line := range strings.Split(string(src), "\n") {
+		argLine, ok := strings.CutPrefix(line, directive)
+		if !ok {
+			continue
+		}
+		found++
+		t.Run(argLine, func(t *testing.T) {
+			args := strings.Fields(argLine)
+			output := filepath.Join(t.TempDir(), "collection.go")
+			require.NoError(t, run(append([]string{"-dir", exampleDir, "-output", output}, args...), os.Stderr))
+			actual, err := os.ReadFile(output)
+			require.NoError(t, err)
+			// The header contains the arguments of the directive only.
+			actual = bytes.Replace(actual, []byte("-dir "+exampleDir+" -output "+output+" "), nil, 1)
+
+			golden := filepath.Join(exampleDir, parseConfig(t, args).fileName())
+			if *update {
+				require.NoError(t, os.WriteFile(golden, actual, 0o644))
+			}
+			expected, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual))
+		})
+	}
+	assert.Equal(t, 4, found)
+}
+
+// parseConfig returns the config of the type and -concurrent flags, which determine the file name.
+func parseConfig(t *testing.T, args []string) config {
+	t.Helper()
+	var cfg config
+	for i, arg := range args {
+		switch arg {
+		case "-type":
+			cfg.typeName = args[i+1]
+		case "-concurrent":
+			cfg.concurrent = true
+		}
+	}
+	return cfg
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config
+		message string
+	}{
+		{
+			name:    "type not found",
+			cfg:     config{typeName: "Town", primary: "ID", keys: []string{"ISO"}},
+			message: "type Town not found",
+		},
+		{
+			name:    "primary not found",
+			cfg:     config{typeName: "City", primary: "Slug", keys: []string{"ISO"}},
+			message: "field Slug not found",
+		},
+		{
+			name:    "key not found",
+			cfg:     config{typeName: "City", primary: "ID", keys: []string{"Slug"}},
+			message: "field Slug not found",
+		},
+		{
+			name:    "key used twice",
+			cfg:     config{typeName: "City", primary: "ID", keys: []string{"ISO", "ISO"}},
+			message: "field ISO is used more than once",
+		},
+		{
+			name:    "primary used as key",
+			cfg:     config{typeName: "City", primary: "ID", keys: []string{"ID"}},
+			message: "field ID is used more than once",
+		},
+		{
+			name:    "no keys",
+			cfg:     config{typeName: "City", primary: "ID"},
+			message: "at least one key field is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generate(exampleDir, tt.cfg)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
+
+func TestGenerate_UnsupportedTypes(t *testing.T) {
+	dir := t.TempDir()
+	src := `package types
+
+import (
+	"time"
+	ext "net/netip"
+)
+
+type Event struct {
+	ID    time.Time
+	Addr  ext.Addr
+	Ratio float64
+	Name  string
+}
+
+type Alias = string
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "types.go"), []byte(src), 0o644))
+
+	_, err := generate(dir, config{typeName: "Event", primary: "ID", keys: []string{"Name", "Ratio"}})
+	require.EqualError(t, err,
+		"key field Ratio has type float64, but a string or an integer type is required with more than one key field")
+	_, err = generate(dir, config{typeName: "Alias", primary: "ID", keys: []string{"Name"}})
+	require.EqualError(t, err, "type Alias is not a struct")
+
+	// The imports of the key types are copied, the others are not.
+	out, err := generate(dir, config{typeName: "Event", primary: "ID", keys: []string{"Addr"}})
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "import (\n\text \"net/netip\"\n\t\"time\"\n\n\t\"github.com/aeimer/go-multikeymap/bikeymap\"\n)")
+	assert.Contains(t, string(out), "base *bikeymap.BiKeyMap[time.Time, ext.Addr, Event]")
+}
+
+func TestRun_RequiredFlags(t *testing.T) {
+	var stderr bytes.Buffer
+	err := run([]string{"-type", "City"}, &stderr)
+	require.EqualError(t, err, "-type, -primary and -keys are required")
+	assert.Contains(t, stderr.String(), "Usage of multikeymap-gen")
+}
+
+func TestNames(t *testing.T) {
+	for name, expected := range map[string][2]string{
+		"Postcode": {"postcode", "postcode"},
+		"ISO":      {"iso", "iso"},
+		"ISOCode":  {"isoCode", "iso_code"},
+		"CityID":   {"cityID", "city_id"},
+		"X":        {"x", "x"},
+	} {
+		assert.Equal(t, expected[0], lowerCamelCase(name), name)
+		assert.Equal(t, expected[1], snakeCase(name), name)
+	}
+}
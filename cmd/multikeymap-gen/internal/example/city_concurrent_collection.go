@@ -0,0 +1,98 @@
+// Code generated by multikeymap-gen -type City -primary ID -keys Postcode,ISO -concurrent; DO NOT EDIT.
+
+package example
+
+import (
+	"strconv"
+	"sync"
+
+	"github.com/aeimer/go-multikeymap/multikeymap"
+)
+
+// ConcurrentCityCollection is a collection of City values indexed by ID, Postcode and ISO.
+// It is safe for concurrent use.
+type ConcurrentCityCollection struct {
+	mu   sync.RWMutex
+	base *multikeymap.MultiKeyMap[int, City]
+}
+
+// NewConcurrentCityCollection creates a new ConcurrentCityCollection instance.
+func NewConcurrentCityCollection(opts ...multikeymap.Option) *ConcurrentCityCollection {
+	return &ConcurrentCityCollection{
+		base: multikeymap.New[int, City](opts...),
+	}
+}
+
+// Add inserts a value or replaces the value with the same ID.
+// It returns a *multikeymap.ConflictError if a key is already used by a value with a different ID.
+// A replaced value keeps its position, the keys it does not share with the new value are removed.
+// The collection is not changed if an error is returned.
+func (c *ConcurrentCityCollection) Add(value City) error {
+	postcodeKey := strconv.FormatInt(int64(value.Postcode), 10)
+	isoKey := value.ISO
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, exists := c.base.GetBySecondaryKey("Postcode", postcodeKey); exists && existing.ID != value.ID {
+		return &multikeymap.ConflictError[int]{Group: "Postcode", Key: postcodeKey, PrimaryKey: value.ID, ExistingPrimaryKey: existing.ID}
+	}
+	if existing, exists := c.base.GetBySecondaryKey("ISO", isoKey); exists && existing.ID != value.ID {
+		return &multikeymap.ConflictError[int]{Group: "ISO", Key: isoKey, PrimaryKey: value.ID, ExistingPrimaryKey: existing.ID}
+	}
+	if old, exists := c.base.Get(value.ID); exists {
+		c.base.RemoveSecondaryKeys(old.ID, "Postcode", strconv.FormatInt(int64(old.Postcode), 10))
+		c.base.RemoveSecondaryKeys(old.ID, "ISO", old.ISO)
+	}
+	c.base.Put(value.ID, value)
+	c.base.PutSecondaryKeys(value.ID, "Postcode", postcodeKey)
+	c.base.PutSecondaryKeys(value.ID, "ISO", isoKey)
+	return nil
+}
+
+// Get returns the value by ID.
+func (c *ConcurrentCityCollection) Get(key int) (City, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.base.Get(key)
+}
+
+// GetByPostcode returns the value by Postcode.
+func (c *ConcurrentCityCollection) GetByPostcode(key int) (City, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.base.GetBySecondaryKey("Postcode", strconv.FormatInt(int64(key), 10))
+}
+
+// GetByISO returns the value by ISO.
+func (c *ConcurrentCityCollection) GetByISO(key string) (City, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.base.GetBySecondaryKey("ISO", key)
+}
+
+// Remove removes the value by ID.
+func (c *ConcurrentCityCollection) Remove(key int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.base.Remove(key)
+}
+
+// Size returns the number of values in the collection.
+func (c *ConcurrentCityCollection) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.base.Size()
+}
+
+// Values returns a slice of all values in the collection.
+func (c *ConcurrentCityCollection) Values() []City {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.base.Values()
+}
+
+// Clear removes all values from the collection.
+func (c *ConcurrentCityCollection) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.base.Clear()
+}
@@ -0,0 +1,61 @@
+// Code generated by multikeymap-gen -type Country -primary Code -keys ISO; DO NOT EDIT.
+
+package example
+
+import (
+	"github.com/aeimer/go-multikeymap/bikeymap"
+)
+
+// CountryCollection is a collection of Country values indexed by Code and ISO.
+// It is not safe for concurrent use.
+type CountryCollection struct {
+	base *bikeymap.BiKeyMap[int, string, Country]
+}
+
+// NewCountryCollection creates a new CountryCollection instance.
+func NewCountryCollection(opts ...bikeymap.Option) *CountryCollection {
+	return &CountryCollection{
+		base: bikeymap.New[int, string, Country](opts...),
+	}
+}
+
+// Add inserts a value or replaces the value with the same Code.
+// It returns a *bikeymap.ConflictError if ISO is already used by a value with a different Code.
+// The collection is not changed if an error is returned.
+func (c *CountryCollection) Add(value Country) error {
+	if existing, exists := c.base.KeyAForKeyB(value.ISO); exists && existing != value.Code {
+		return &bikeymap.ConflictError[int, string]{Err: bikeymap.ErrKeyBConflict, KeyA: value.Code, KeyB: value.ISO, ExistingKeyA: existing}
+	}
+	_ = c.base.RemoveByKeyA(value.Code)
+	return c.base.Put(value.Code, value.ISO, value)
+}
+
+// Get returns the value by Code.
+func (c *CountryCollection) Get(key int) (Country, bool) {
+	return c.base.GetByKeyA(key)
+}
+
+// GetByISO returns the value by ISO.
+func (c *CountryCollection) GetByISO(key string) (Country, bool) {
+	return c.base.GetByKeyB(key)
+}
+
+// Remove removes the value by Code.
+func (c *CountryCollection) Remove(key int) {
+	_ = c.base.RemoveByKeyA(key)
+}
+
+// Size returns the number of values in the collection.
+func (c *CountryCollection) Size() int {
+	return c.base.Size()
+}
+
+// Values returns a slice of all values in the collection.
+func (c *CountryCollection) Values() []Country {
+	return c.base.Values()
+}
+
+// Clear removes all values from the collection.
+func (c *CountryCollection) Clear() {
+	c.base.Clear()
+}
@@ -0,0 +1,78 @@
+// Code generated by multikeymap-gen -type Country -primary Code -keys ISO -concurrent; DO NOT EDIT.
+
+package example
+
+import (
+	"sync"
+
+	"github.com/aeimer/go-multikeymap/bikeymap"
+)
+
+// ConcurrentCountryCollection is a collection of Country values indexed by Code and ISO.
+// It is safe for concurrent use.
+type ConcurrentCountryCollection struct {
+	mu   sync.RWMutex
+	base *bikeymap.BiKeyMap[int, string, Country]
+}
+
+// NewConcurrentCountryCollection creates a new ConcurrentCountryCollection instance.
+func NewConcurrentCountryCollection(opts ...bikeymap.Option) *ConcurrentCountryCollection {
+	return &ConcurrentCountryCollection{
+		base: bikeymap.New[int, string, Country](opts...),
+	}
+}
+
+// Add inserts a value or replaces the value with the same Code.
+// It returns a *bikeymap.ConflictError if ISO is already used by a value with a different Code.
+// The collection is not changed if an error is returned.
+func (c *ConcurrentCountryCollection) Add(value Country) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if existing, exists := c.base.KeyAForKeyB(value.ISO); exists && existing != value.Code {
+		return &bikeymap.ConflictError[int, string]{Err: bikeymap.ErrKeyBConflict, KeyA: value.Code, KeyB: value.ISO, ExistingKeyA: existing}
+	}
+	_ = c.base.RemoveByKeyA(value.Code)
+	return c.base.Put(value.Code, value.ISO, value)
+}
+
+// Get returns the value by Code.
+func (c *ConcurrentCountryCollection) Get(key int) (Country, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.base.GetByKeyA(key)
+}
+
+// GetByISO returns the value by ISO.
+func (c *ConcurrentCountryCollection) GetByISO(key string) (Country, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.base.GetByKeyB(key)
+}
+
+// Remove removes the value by Code.
+func (c *ConcurrentCountryCollection) Remove(key int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_ = c.base.RemoveByKeyA(key)
+}
+
+// Size returns the number of values in the collection.
+func (c *ConcurrentCountryCollection) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.base.Size()
+}
+
+// Values returns a slice of all values in the collection.
+func (c *ConcurrentCountryCollection) Values() []Country {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.base.Values()
+}
+
+// Clear removes all values from the collection.
+func (c *ConcurrentCountryCollection) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.base.Clear()
+}
@@ -0,0 +1,25 @@
+// Package example contains collections generated by multikeymap-gen.
+// The generated files are the golden files of the generator tests.
+package example
+
+//go:generate go run github.com/aeimer/go-multikeymap/cmd/multikeymap-gen -type City -primary ID -keys Postcode,ISO
+//go:generate go run github.com/aeimer/go-multikeymap/cmd/multikeymap-gen -type City -primary ID -keys Postcode,ISO -concurrent
+
+// City is indexed by ID, Postcode and ISO.
+type City struct {
+	ID         int
+	Postcode   int
+	ISO        string
+	Name       string
+	Population int
+}
+
+//go:generate go run github.com/aeimer/go-multikeymap/cmd/multikeymap-gen -type Country -primary Code -keys ISO
+//go:generate go run github.com/aeimer/go-multikeymap/cmd/multikeymap-gen -type Country -primary Code -keys ISO -concurrent
+
+// Country is indexed by its numeric ISO 3166 Code and its alpha-2 ISO code.
+type Country struct {
+	Code int
+	ISO  string
+	Name string
+}
@@ -0,0 +1,138 @@
+package example
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/bikeymap"
+	"github.com/aeimer/go-multikeymap/multikeymap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleCityCollection() {
+	cities := NewCityCollection()
+	_ = cities.Add(City{ID: 1, Postcode: 10115, ISO: "DE BER", Name: "Berlin"})
+	city, exists := cities.GetByPostcode(10115)
+	fmt.Printf("value: %v, exists: %v\n", city.Name, exists)
+
+	// Output:
+	// value: Berlin, exists: true
+}
+
+func TestCityCollection(t *testing.T) {
+	for name, c := range map[string]interface {
+		Add(value City) error
+		Get(key int) (City, bool)
+		GetByPostcode(key int) (City, bool)
+		GetByISO(key string) (City, bool)
+		Remove(key int)
+		Size() int
+		Values() []City
+		Clear()
+	}{
+		"CityCollection":           NewCityCollection(multikeymap.WithInterning(), multikeymap.WithInsertionOrder()),
+		"ConcurrentCityCollection": NewConcurrentCityCollection(multikeymap.WithInsertionOrder()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			berlin := City{ID: 1, Postcode: 10115, ISO: "DE BER", Name: "Berlin"}
+			require.NoError(t, c.Add(berlin))
+			require.NoError(t, c.Add(City{ID: 2, Postcode: 20095, ISO: "DE HAM", Name: "Hamburg"}))
+
+			city, exists := c.GetByPostcode(10115)
+			assert.True(t, exists)
+			assert.Equal(t, berlin, city)
+			city, exists = c.GetByISO("DE HAM")
+			assert.True(t, exists)
+			assert.Equal(t, "Hamburg", city.Name)
+
+			err := c.Add(City{ID: 3, Postcode: 10115, ISO: "DE XXX"})
+			var conflictErr *multikeymap.ConflictError[int]
+			require.ErrorAs(t, err, &conflictErr)
+			assert.Equal(t, multikeymap.ConflictError[int]{Group: "Postcode", Key: "10115", PrimaryKey: 3, ExistingPrimaryKey: 1}, *conflictErr)
+			_, exists = c.GetByISO("DE XXX")
+			assert.False(t, exists)
+
+			require.NoError(t, c.Add(City{ID: 1, Postcode: 10117, ISO: "DE BER", Name: "Berlin"}))
+			_, exists = c.GetByPostcode(10115)
+			assert.False(t, exists)
+			city, _ = c.GetByPostcode(10117)
+			assert.Equal(t, 1, city.ID)
+			city, exists = c.GetByISO("DE BER")
+			assert.True(t, exists)
+			assert.Equal(t, 10117, city.Postcode)
+			assert.Equal(t, []string{"Berlin", "Hamburg"}, []string{c.Values()[0].Name, c.Values()[1].Name})
+			assert.Equal(t, 2, c.Size())
+
+			c.Remove(2)
+			_, exists = c.GetByISO("DE HAM")
+			assert.False(t, exists)
+			assert.Len(t, c.Values(), 1)
+			c.Clear()
+			assert.Equal(t, 0, c.Size())
+		})
+	}
+}
+
+func TestCountryCollection(t *testing.T) {
+	for name, c := range map[string]interface {
+		Add(value Country) error
+		Get(key int) (Country, bool)
+		GetByISO(key string) (Country, bool)
+		Remove(key int)
+		Size() int
+		Values() []Country
+		Clear()
+	}{
+		"CountryCollection":           NewCountryCollection(bikeymap.WithBloomFilter(), bikeymap.WithInsertionOrder()),
+		"ConcurrentCountryCollection": NewConcurrentCountryCollection(bikeymap.WithInsertionOrder()),
+	} {
+		t.Run(name, func(t *testing.T) {
+			germany := Country{Code: 276, ISO: "DE", Name: "Germany"}
+			require.NoError(t, c.Add(germany))
+
+			country, exists := c.GetByISO("DE")
+			assert.True(t, exists)
+			assert.Equal(t, germany, country)
+
+			err := c.Add(Country{Code: 250, ISO: "DE", Name: "France"})
+			require.ErrorIs(t, err, bikeymap.ErrKeyBConflict)
+			_, exists = c.Get(250)
+			assert.False(t, exists)
+
+			require.NoError(t, c.Add(Country{Code: 276, ISO: "DEU", Name: "Germany"}))
+			_, exists = c.GetByISO("DE")
+			assert.False(t, exists)
+			country, _ = c.Get(276)
+			assert.Equal(t, "DEU", country.ISO)
+
+			c.Remove(276)
+			assert.Equal(t, 0, c.Size())
+			assert.Empty(t, c.Values())
+			c.Clear()
+		})
+	}
+}
+
+func TestConcurrentCityCollection_Parallel(t *testing.T) {
+	c := NewConcurrentCityCollection()
+	var wg sync.WaitGroup
+	for worker := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range 100 {
+				id := worker*100 + n
+				assert.NoError(t, c.Add(City{ID: id, Postcode: id, ISO: fmt.Sprint(id)}))
+				_, _ = c.GetByPostcode(id)
+				_ = c.Size()
+				if n%2 == 0 {
+					c.Remove(id)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 400, c.Size())
+}
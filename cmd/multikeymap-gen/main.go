@@ -0,0 +1,71 @@
+// Command multikeymap-gen generates a type-safe collection for a struct type,
+// which is indexed by a primary key field and one or more key fields.
+//
+// It is meant to be used with go:generate in the package which declares the struct:
+//
+//	//go:generate go run github.com/aeimer/go-multikeymap/cmd/multikeymap-gen -type City -primary ID -keys Postcode,ISO
+//
+// For every key field the collection has a method like GetByPostcode(key int).
+// With one key field the collection is built on bikeymap.BiKeyMap, otherwise on multikeymap.MultiKeyMap.
+// The key fields of a MultiKeyMap collection must have a string or an integer type.
+//
+// Usage:
+//
+//	multikeymap-gen -type T -primary Field -keys Field[,Field...] [-concurrent] [-name Name] [-output file] [-dir dir]
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "multikeymap-gen:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run parses the command line arguments, generates the collection and writes it to the output file.
+func run(args []string, stderr io.Writer) error {
+	flags := flag.NewFlagSet("multikeymap-gen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	typeName := flags.String("type", "", "name of the struct type (required)")
+	primary := flags.String("primary", "", "name of the primary key field (required)")
+	keys := flags.String("keys", "", "comma-separated names of the key fields (required)")
+	concurrent := flags.Bool("concurrent", false, "generate a collection which is safe for concurrent use")
+	name := flags.String("name", "", "name of the collection type (default [Concurrent]<type>Collection)")
+	output := flags.String("output", "", "output file name (default <type>[_concurrent]_collection.go in dir)")
+	dir := flags.String("dir", ".", "directory of the package which declares the type")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *typeName == "" || *primary == "" || *keys == "" {
+		flags.Usage()
+		return errors.New("-type, -primary and -keys are required")
+	}
+
+	cfg := config{
+		typeName:   *typeName,
+		primary:    *primary,
+		keys:       strings.Split(*keys, ","),
+		concurrent: *concurrent,
+		name:       *name,
+		args:       args,
+	}
+	src, err := generate(*dir, cfg)
+	if err != nil {
+		return err
+	}
+	if *output == "" {
+		*output = filepath.Join(*dir, cfg.fileName())
+	}
+	return os.WriteFile(*output, src, 0o644)
+}
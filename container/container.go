@@ -1,5 +1,7 @@
 package container
 
+import "iter"
+
 // Container is the interface that all multikeymaps must implement.
 type Container[T any] interface {
 	Empty() bool
@@ -8,3 +10,14 @@ type Container[T any] interface {
 	Clear()
 	String() string
 }
+
+// KeyedContainer is a Container whose values can be accessed by a key.
+// The maps with multiple keys use their main key, e.g. the primary key or KeyA.
+// BiMultiMap is a relation without a value per key, so it does not implement KeyedContainer.
+type KeyedContainer[K any, V any] interface {
+	Container[V]
+	Keys() []K
+	Has(key K) bool
+	Get(key K) (V, bool)
+	All() iter.Seq2[K, V]
+}
@@ -0,0 +1,210 @@
+package containertest
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/container"
+)
+
+// BiKeyMap is the behavior of bikeymap.BiKeyMap which is checked by TestBiKeyMap.
+type BiKeyMap[KeyA comparable, KeyB comparable, V any] interface {
+	container.Container[V]
+	Put(keyA KeyA, keyB KeyB, value V) error
+	GetByKeyA(keyA KeyA) (V, bool)
+	GetByKeyB(keyB KeyB) (V, bool)
+	RemoveByKeyA(keyA KeyA) error
+	RemoveByKeyB(keyB KeyB) error
+}
+
+var (
+	biKeyMapKeysA = []string{"keyA1", "keyA2", "keyA3", "keyA4"}
+	biKeyMapKeysB = []int{1, 2, 3, 4}
+)
+
+// TestBiKeyMap runs the conformance suite for the BiKeyMap behavior.
+// The function newMap must return a new empty map.
+// Besides some fixed scenarios, it checks randomized operation sequences against a reference implementation.
+func TestBiKeyMap(t *testing.T, newMap func() BiKeyMap[string, int, int]) {
+	t.Helper()
+
+	t.Run("Container", func(t *testing.T) {
+		TestContainer(t, func(values []int) container.Container[int] {
+			m := newMap()
+			for i, value := range values {
+				if err := m.Put(fmt.Sprintf("keyA%d", i), i, value); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			return m
+		})
+	})
+
+	t.Run("PutAndGet", func(t *testing.T) {
+		m := newMap()
+		if err := m.Put("keyA1", 1, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := m.Put("keyA1", 1, 2); err != nil {
+			t.Fatalf("unexpected error when updating the value: %v", err)
+		}
+		if value, exists := m.GetByKeyA("keyA1"); !exists || value != 2 {
+			t.Errorf("expected value 2, got %v, exists: %v", value, exists)
+		}
+		if value, exists := m.GetByKeyB(1); !exists || value != 2 {
+			t.Errorf("expected value 2, got %v, exists: %v", value, exists)
+		}
+	})
+
+	t.Run("Conflicts", func(t *testing.T) {
+		m := newMap()
+		if err := m.Put("keyA1", 1, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := m.Put("keyA2", 1, 2); err == nil {
+			t.Error("expected error when keyB is paired with a different keyA")
+		}
+		if err := m.Put("keyA1", 2, 2); err == nil {
+			t.Error("expected error when keyA is paired with a different keyB")
+		}
+		if err := m.RemoveByKeyA("keyA2"); err == nil {
+			t.Error("expected error when removing a missing keyA")
+		}
+		if err := m.RemoveByKeyB(2); err == nil {
+			t.Error("expected error when removing a missing keyB")
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		for seed := range uint64(randomRuns) {
+			if err := runBiKeyMapOperations(newMap(), seed); err != nil {
+				t.Fatalf("seed %d: %v", seed, err)
+			}
+		}
+	})
+}
+
+// runBiKeyMapOperations applies random operations to the map and the reference implementation
+// and compares them after every operation.
+func runBiKeyMapOperations(m BiKeyMap[string, int, int], seed uint64) error {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	model := newBiKeyMapModel()
+	for i := range randomOperations {
+		keyA := biKeyMapKeysA[rng.IntN(len(biKeyMapKeysA))]
+		keyB := biKeyMapKeysB[rng.IntN(len(biKeyMapKeysB))]
+
+		var operation string
+		var err, expectedErr error
+		switch op := rng.IntN(20); {
+		case op < 10:
+			value := rng.IntN(100)
+			operation = fmt.Sprintf("Put(%q, %d, %d)", keyA, keyB, value)
+			err, expectedErr = m.Put(keyA, keyB, value), model.put(keyA, keyB, value)
+		case op < 14:
+			operation = fmt.Sprintf("RemoveByKeyA(%q)", keyA)
+			err, expectedErr = m.RemoveByKeyA(keyA), model.removeByKeyA(keyA)
+		case op < 19:
+			operation = fmt.Sprintf("RemoveByKeyB(%d)", keyB)
+			err, expectedErr = m.RemoveByKeyB(keyB), model.removeByKeyB(keyB)
+		default:
+			operation = "Clear()"
+			m.Clear()
+			model = newBiKeyMapModel()
+		}
+
+		if (err == nil) != (expectedErr == nil) {
+			return fmt.Errorf("operation %d %s: expected error %v, got %v", i, operation, expectedErr, err)
+		}
+		if err := model.compare(m); err != nil {
+			return fmt.Errorf("after operation %d %s: %w", i, operation, err)
+		}
+	}
+	return nil
+}
+
+// biKeyMapModel is the reference implementation of the BiKeyMap behavior.
+type biKeyMapModel struct {
+	values     map[string]int
+	keyBByKeyA map[string]int
+}
+
+func newBiKeyMapModel() *biKeyMapModel {
+	return &biKeyMapModel{
+		values:     make(map[string]int),
+		keyBByKeyA: make(map[string]int),
+	}
+}
+
+func (m *biKeyMapModel) keyAForKeyB(keyB int) (string, bool) {
+	for keyA, otherKeyB := range m.keyBByKeyA {
+		if otherKeyB == keyB {
+			return keyA, true
+		}
+	}
+	return "", false
+}
+
+func (m *biKeyMapModel) put(keyA string, keyB int, value int) error {
+	if existingKeyA, exists := m.keyAForKeyB(keyB); exists && existingKeyA != keyA {
+		return fmt.Errorf("keyB %d is paired with %q", keyB, existingKeyA)
+	}
+	if existingKeyB, exists := m.keyBByKeyA[keyA]; exists && existingKeyB != keyB {
+		return fmt.Errorf("keyA %q is paired with %d", keyA, existingKeyB)
+	}
+	m.values[keyA] = value
+	m.keyBByKeyA[keyA] = keyB
+	return nil
+}
+
+func (m *biKeyMapModel) removeByKeyA(keyA string) error {
+	if _, exists := m.keyBByKeyA[keyA]; !exists {
+		return fmt.Errorf("keyA %q does not exist", keyA)
+	}
+	delete(m.values, keyA)
+	delete(m.keyBByKeyA, keyA)
+	return nil
+}
+
+func (m *biKeyMapModel) removeByKeyB(keyB int) error {
+	keyA, exists := m.keyAForKeyB(keyB)
+	if !exists {
+		return fmt.Errorf("keyB %d does not exist", keyB)
+	}
+	return m.removeByKeyA(keyA)
+}
+
+func (m *biKeyMapModel) compare(actual BiKeyMap[string, int, int]) error {
+	if actual.Size() != len(m.values) {
+		return fmt.Errorf("expected size %d, got %d", len(m.values), actual.Size())
+	}
+	if actual.Empty() != (len(m.values) == 0) {
+		return fmt.Errorf("expected empty to be %v", len(m.values) == 0)
+	}
+	if err := sameElements(slices.Collect(maps.Values(m.values)), actual.Values()); err != nil {
+		return err
+	}
+	return m.compareKeys(actual, biKeyMapKeysA, biKeyMapKeysB)
+}
+
+// compareKeys compares the lookups of the given keys.
+func (m *biKeyMapModel) compareKeys(actual BiKeyMap[string, int, int], keysA []string, keysB []int) error {
+	for _, keyA := range keysA {
+		expected, expectedExists := m.values[keyA]
+		value, exists := actual.GetByKeyA(keyA)
+		if exists != expectedExists || value != expected {
+			return fmt.Errorf("GetByKeyA(%q): expected %v, %v, got %v, %v", keyA, expected, expectedExists, value, exists)
+		}
+	}
+	for _, keyB := range keysB {
+		keyA, _ := m.keyAForKeyB(keyB)
+		expected, expectedExists := m.values[keyA]
+		value, exists := actual.GetByKeyB(keyB)
+		if exists != expectedExists || value != expected {
+			return fmt.Errorf("GetByKeyB(%d): expected %v, %v, got %v, %v", keyB, expected, expectedExists, value, exists)
+		}
+	}
+	return nil
+}
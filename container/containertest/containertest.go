@@ -0,0 +1,103 @@
+// Package containertest implements conformance test suites for implementations of container.Container,
+// the MultiKeyMap and the BiKeyMap behavior.
+// The suites can be used to verify wrappers and decorators around the maps of this module.
+// The stress suites for concurrent maps are meant to be run with the race detector.
+package containertest
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/container"
+)
+
+// randomRuns is the number of randomized operation sequences checked by the map suites.
+const randomRuns = 50
+
+// randomOperations is the number of operations of a randomized operation sequence.
+const randomOperations = 200
+
+// TestContainer runs the conformance suite for container.Container.
+// The function newContainer must return a new container holding exactly the given values.
+func TestContainer(t *testing.T, newContainer func(values []int) container.Container[int]) {
+	t.Helper()
+
+	t.Run("Empty", func(t *testing.T) {
+		if c := newContainer(nil); !c.Empty() {
+			t.Error("expected container without values to be empty")
+		}
+		if c := newContainer([]int{1}); c.Empty() {
+			t.Error("expected container with values to not be empty")
+		}
+	})
+
+	t.Run("Size", func(t *testing.T) {
+		for _, values := range [][]int{nil, {1}, {1, 2, 3}} {
+			if size := newContainer(values).Size(); size != len(values) {
+				t.Errorf("expected size %d, got %d", len(values), size)
+			}
+		}
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		values := []int{3, 1, 2}
+		if err := sameElements(values, newContainer(values).Values()); err != nil {
+			t.Error(err)
+		}
+		if got := newContainer(nil).Values(); len(got) != 0 {
+			t.Errorf("expected no values, got %v", got)
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		c := newContainer([]int{1, 2, 3})
+		c.Clear()
+		if !c.Empty() || c.Size() != 0 || len(c.Values()) != 0 {
+			t.Errorf("expected container to be empty after clear, got %v", c.Values())
+		}
+		c.Clear()
+		if !c.Empty() {
+			t.Error("expected clearing an empty container to keep it empty")
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		if newContainer([]int{1}).String() == "" {
+			t.Error("expected a non-empty string")
+		}
+		if newContainer([]int{1}).String() != newContainer([]int{1}).String() {
+			t.Error("expected containers with the same value to have the same string")
+		}
+		if newContainer([]int{1}).String() == newContainer([]int{2}).String() {
+			t.Error("expected containers with different values to have different strings")
+		}
+	})
+}
+
+// sameElements checks if both slices contain the same elements in any order.
+func sameElements[T comparable](expected, actual []T) error {
+	counts := make(map[T]int, len(expected))
+	for _, value := range expected {
+		counts[value]++
+	}
+	for _, value := range actual {
+		counts[value]--
+	}
+	for _, count := range counts {
+		if count != 0 {
+			return fmt.Errorf("expected values %v, got %v", expected, actual)
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of the map in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,157 @@
+package containertest_test
+
+import (
+	"fmt"
+	"hash/maphash"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/bikeymap"
+	"github.com/aeimer/go-multikeymap/container"
+	"github.com/aeimer/go-multikeymap/container/containertest"
+	"github.com/aeimer/go-multikeymap/multikeymap"
+)
+
+// sliceContainer is a minimal container.Container to verify TestContainer itself.
+type sliceContainer struct {
+	values []int
+}
+
+func (c *sliceContainer) Empty() bool    { return len(c.values) == 0 }
+func (c *sliceContainer) Size() int      { return len(c.values) }
+func (c *sliceContainer) Values() []int  { return c.values }
+func (c *sliceContainer) Clear()         { c.values = nil }
+func (c *sliceContainer) String() string { return fmt.Sprintf("sliceContainer: %v", c.values) }
+
+func TestTestContainer(t *testing.T) {
+	containertest.TestContainer(t, func(values []int) container.Container[int] {
+		return &sliceContainer{values: values}
+	})
+}
+
+func TestTestMultiKeyMap(t *testing.T) {
+	t.Run("MultiKeyMap", func(t *testing.T) {
+		containertest.TestMultiKeyMap(t, func() containertest.MultiKeyMap[string, int] {
+			return multikeymap.New[string, int]()
+		})
+	})
+	t.Run("ConcurrentMultiKeyMap", func(t *testing.T) {
+		containertest.TestMultiKeyMap(t, func() containertest.MultiKeyMap[string, int] {
+			return multikeymap.NewConcurrent[string, int]()
+		})
+	})
+	t.Run("MultiKeyMapWithInterning", func(t *testing.T) {
+		containertest.TestMultiKeyMap(t, func() containertest.MultiKeyMap[string, int] {
+			return multikeymap.New[string, int](multikeymap.WithInterning())
+		})
+	})
+	t.Run("MultiKeyMapWithInsertionOrder", func(t *testing.T) {
+		containertest.TestMultiKeyMap(t, func() containertest.MultiKeyMap[string, int] {
+			return multikeymap.New[string, int](multikeymap.WithInsertionOrder())
+		})
+	})
+	t.Run("MultiKeyMapWithBloomFilter", func(t *testing.T) {
+		containertest.TestMultiKeyMap(t, func() containertest.MultiKeyMap[string, int] {
+			return multikeymap.New[string, int](multikeymap.WithBloomFilter())
+		})
+	})
+	t.Run("SlabMultiKeyMap", func(t *testing.T) {
+		containertest.TestMultiKeyMap(t, func() containertest.MultiKeyMap[string, int] {
+			return multikeymap.NewSlab[string, int]()
+		})
+	})
+	t.Run("SlabMultiKeyMapWithOptions", func(t *testing.T) {
+		containertest.TestMultiKeyMap(t, func() containertest.MultiKeyMap[string, int] {
+			return multikeymap.NewSlab[string, int](multikeymap.WithInterning(), multikeymap.WithBloomFilter())
+		})
+	})
+	t.Run("OrderedMultiKeyMap", func(t *testing.T) {
+		containertest.TestMultiKeyMap(t, func() containertest.MultiKeyMap[string, int] {
+			return multikeymap.NewOrdered[string, int]()
+		})
+	})
+}
+
+func TestTestBiKeyMap(t *testing.T) {
+	t.Run("BiKeyMap", func(t *testing.T) {
+		containertest.TestBiKeyMap(t, func() containertest.BiKeyMap[string, int, int] {
+			return bikeymap.New[string, int, int]()
+		})
+	})
+	t.Run("ConcurrentBiKeyMap", func(t *testing.T) {
+		containertest.TestBiKeyMap(t, func() containertest.BiKeyMap[string, int, int] {
+			return bikeymap.NewConcurrent[string, int, int]()
+		})
+	})
+	t.Run("BiKeyMapWithInsertionOrder", func(t *testing.T) {
+		containertest.TestBiKeyMap(t, func() containertest.BiKeyMap[string, int, int] {
+			return bikeymap.New[string, int, int](bikeymap.WithInsertionOrder())
+		})
+	})
+	t.Run("BiKeyMapWithBloomFilter", func(t *testing.T) {
+		containertest.TestBiKeyMap(t, func() containertest.BiKeyMap[string, int, int] {
+			return bikeymap.New[string, int, int](bikeymap.WithBloomFilter())
+		})
+	})
+	t.Run("OrderedBiKeyMap", func(t *testing.T) {
+		containertest.TestBiKeyMap(t, func() containertest.BiKeyMap[string, int, int] {
+			return bikeymap.NewOrdered[string, int, int]()
+		})
+	})
+}
+
+func TestTestConcurrentMultiKeyMap(t *testing.T) {
+	t.Run("ConcurrentMultiKeyMap", func(t *testing.T) {
+		containertest.TestConcurrentMultiKeyMap(t, func() containertest.ConcurrentMultiKeyMap[string, int] {
+			return multikeymap.NewConcurrent[string, int]()
+		})
+	})
+	t.Run("ConcurrentMultiKeyMapWithInterning", func(t *testing.T) {
+		containertest.TestConcurrentMultiKeyMap(t, func() containertest.ConcurrentMultiKeyMap[string, int] {
+			return multikeymap.NewConcurrent[string, int](multikeymap.WithInterning())
+		})
+	})
+	t.Run("ConcurrentMultiKeyMapWithInsertionOrder", func(t *testing.T) {
+		containertest.TestConcurrentMultiKeyMap(t, func() containertest.ConcurrentMultiKeyMap[string, int] {
+			return multikeymap.NewConcurrent[string, int](multikeymap.WithInsertionOrder())
+		})
+	})
+}
+
+func TestTestConcurrentBiKeyMap(t *testing.T) {
+	t.Run("ConcurrentBiKeyMap", func(t *testing.T) {
+		containertest.TestConcurrentBiKeyMap(t, func() containertest.ConcurrentBiKeyMap[string, int, int] {
+			return bikeymap.NewConcurrent[string, int, int]()
+		})
+	})
+	t.Run("ConcurrentBiKeyMapWithInsertionOrder", func(t *testing.T) {
+		containertest.TestConcurrentBiKeyMap(t, func() containertest.ConcurrentBiKeyMap[string, int, int] {
+			return bikeymap.NewConcurrent[string, int, int](bikeymap.WithInsertionOrder())
+		})
+	})
+}
+
+var testSeed = maphash.MakeSeed()
+
+func hashString(key string) uint64 {
+	return maphash.String(testSeed, key)
+}
+
+func hashInt(key int) uint64 {
+	return uint64(key) * 0x9e3779b97f4a7c15
+}
+
+func TestTestHashedMaps(t *testing.T) {
+	t.Run("HashedMultiKeyMap", func(t *testing.T) {
+		containertest.TestMultiKeyMap(t, func() containertest.MultiKeyMap[string, int] {
+			return multikeymap.NewWithHasher[string, int](hashString, func(a, b string) bool { return a == b })
+		})
+	})
+	t.Run("HashedBiKeyMap", func(t *testing.T) {
+		containertest.TestBiKeyMap(t, func() containertest.BiKeyMap[string, int, int] {
+			return bikeymap.NewWithHasher[string, int, int](
+				hashString, func(a, b string) bool { return a == b },
+				hashInt, func(a, b int) bool { return a == b },
+			)
+		})
+	})
+}
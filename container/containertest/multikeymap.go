@@ -0,0 +1,205 @@
+package containertest
+
+import (
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/container"
+)
+
+// MultiKeyMap is the behavior of multikeymap.MultiKeyMap which is checked by TestMultiKeyMap.
+type MultiKeyMap[K comparable, V any] interface {
+	container.Container[V]
+	Put(primaryKey K, value V)
+	PutSecondaryKeys(primaryKey K, group string, keys ...string)
+	HasPrimaryKey(primaryKey K) bool
+	HasSecondaryKey(group string, key string) bool
+	GetAllKeyGroups() map[string]map[string]K
+	Remove(primaryKey K)
+	Get(primaryKey K) (V, bool)
+	GetBySecondaryKey(group string, key string) (V, bool)
+}
+
+var (
+	multiKeyMapPrimaryKeys = []string{"key1", "key2", "key3", "key4", "key5"}
+	multiKeyMapGroups      = []string{"group1", "group2"}
+	multiKeyMapKeys        = []string{"secKey1", "secKey2", "secKey3", "secKey4"}
+)
+
+// TestMultiKeyMap runs the conformance suite for the MultiKeyMap behavior.
+// The function newMap must return a new empty map.
+// Besides some fixed scenarios, it checks randomized operation sequences against a reference implementation.
+func TestMultiKeyMap(t *testing.T, newMap func() MultiKeyMap[string, int]) {
+	t.Helper()
+
+	t.Run("Container", func(t *testing.T) {
+		TestContainer(t, func(values []int) container.Container[int] {
+			m := newMap()
+			for i, value := range values {
+				m.Put(fmt.Sprintf("key%d", i), value)
+			}
+			return m
+		})
+	})
+
+	t.Run("PutAndGet", func(t *testing.T) {
+		m := newMap()
+		m.Put("key1", 1)
+		m.Put("key1", 2)
+		if value, exists := m.Get("key1"); !exists || value != 2 {
+			t.Errorf("expected value 2, got %v, exists: %v", value, exists)
+		}
+		if _, exists := m.Get("key2"); exists {
+			t.Error("expected key2 to not exist")
+		}
+	})
+
+	t.Run("SecondaryKeys", func(t *testing.T) {
+		m := newMap()
+		m.Put("key1", 1)
+		m.PutSecondaryKeys("key1", "group1", "secKey1", "secKey2")
+		for _, key := range []string{"secKey1", "secKey2"} {
+			if value, exists := m.GetBySecondaryKey("group1", key); !exists || value != 1 {
+				t.Errorf("expected value 1 for %s, got %v, exists: %v", key, value, exists)
+			}
+		}
+		m.Remove("key1")
+		if m.HasSecondaryKey("group1", "secKey1") || m.HasSecondaryKey("group1", "secKey2") {
+			t.Error("expected secondary keys to be removed with their primary key")
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		for seed := range uint64(randomRuns) {
+			if err := runMultiKeyMapOperations(newMap(), seed); err != nil {
+				t.Fatalf("seed %d: %v", seed, err)
+			}
+		}
+	})
+}
+
+// runMultiKeyMapOperations applies random operations to the map and the reference implementation
+// and compares them after every operation.
+func runMultiKeyMapOperations(m MultiKeyMap[string, int], seed uint64) error {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	model := newMultiKeyMapModel()
+	for i := range randomOperations {
+		primaryKey := multiKeyMapPrimaryKeys[rng.IntN(len(multiKeyMapPrimaryKeys))]
+		group := multiKeyMapGroups[rng.IntN(len(multiKeyMapGroups))]
+		key := multiKeyMapKeys[rng.IntN(len(multiKeyMapKeys))]
+
+		var operation string
+		switch op := rng.IntN(20); {
+		case op < 8:
+			value := rng.IntN(100)
+			operation = fmt.Sprintf("Put(%q, %d)", primaryKey, value)
+			m.Put(primaryKey, value)
+			model.put(primaryKey, value)
+		case op < 14:
+			operation = fmt.Sprintf("PutSecondaryKeys(%q, %q, %q)", primaryKey, group, key)
+			m.PutSecondaryKeys(primaryKey, group, key)
+			model.putSecondaryKey(primaryKey, group, key)
+		case op < 19:
+			operation = fmt.Sprintf("Remove(%q)", primaryKey)
+			m.Remove(primaryKey)
+			model.remove(primaryKey)
+		default:
+			operation = "Clear()"
+			m.Clear()
+			model = newMultiKeyMapModel()
+		}
+
+		if err := model.compare(m); err != nil {
+			return fmt.Errorf("after operation %d %s: %w", i, operation, err)
+		}
+	}
+	return nil
+}
+
+// multiKeyMapModel is the reference implementation of the MultiKeyMap behavior.
+type multiKeyMapModel struct {
+	values    map[string]int
+	secondary map[string]map[string]string
+}
+
+func newMultiKeyMapModel() *multiKeyMapModel {
+	return &multiKeyMapModel{
+		values:    make(map[string]int),
+		secondary: make(map[string]map[string]string),
+	}
+}
+
+func (m *multiKeyMapModel) put(primaryKey string, value int) {
+	m.values[primaryKey] = value
+}
+
+func (m *multiKeyMapModel) putSecondaryKey(primaryKey string, group string, key string) {
+	if m.secondary[group] == nil {
+		m.secondary[group] = make(map[string]string)
+	}
+	m.secondary[group][key] = primaryKey
+}
+
+func (m *multiKeyMapModel) remove(primaryKey string) {
+	delete(m.values, primaryKey)
+	for group, keys := range m.secondary {
+		maps.DeleteFunc(keys, func(_ string, owner string) bool {
+			return owner == primaryKey
+		})
+		if len(keys) == 0 {
+			delete(m.secondary, group)
+		}
+	}
+}
+
+func (m *multiKeyMapModel) compare(actual MultiKeyMap[string, int]) error {
+	if actual.Size() != len(m.values) {
+		return fmt.Errorf("expected size %d, got %d", len(m.values), actual.Size())
+	}
+	if actual.Empty() != (len(m.values) == 0) {
+		return fmt.Errorf("expected empty to be %v", len(m.values) == 0)
+	}
+	if err := sameElements(slices.Collect(maps.Values(m.values)), actual.Values()); err != nil {
+		return err
+	}
+	if err := m.compareKeys(actual, multiKeyMapPrimaryKeys, multiKeyMapKeys); err != nil {
+		return err
+	}
+	groups := actual.GetAllKeyGroups()
+	if !maps.EqualFunc(m.secondary, groups, maps.Equal) {
+		return fmt.Errorf("GetAllKeyGroups: expected groups %v, got %v", sortedKeys(m.secondary), sortedKeys(groups))
+	}
+	return nil
+}
+
+// compareKeys compares the lookups of the given primary keys and secondary keys of all groups.
+func (m *multiKeyMapModel) compareKeys(actual MultiKeyMap[string, int], primaryKeys []string, keys []string) error {
+	for _, primaryKey := range primaryKeys {
+		expected, expectedExists := m.values[primaryKey]
+		value, exists := actual.Get(primaryKey)
+		if exists != expectedExists || value != expected {
+			return fmt.Errorf("Get(%q): expected %v, %v, got %v, %v", primaryKey, expected, expectedExists, value, exists)
+		}
+		if actual.HasPrimaryKey(primaryKey) != expectedExists {
+			return fmt.Errorf("HasPrimaryKey(%q): expected %v", primaryKey, expectedExists)
+		}
+	}
+	for _, group := range multiKeyMapGroups {
+		for _, key := range keys {
+			owner, expectedHas := m.secondary[group][key]
+			expected, expectedExists := m.values[owner]
+			if actual.HasSecondaryKey(group, key) != expectedHas {
+				return fmt.Errorf("HasSecondaryKey(%q, %q): expected %v", group, key, expectedHas)
+			}
+			value, exists := actual.GetBySecondaryKey(group, key)
+			if exists != expectedExists || value != expected {
+				return fmt.Errorf("GetBySecondaryKey(%q, %q): expected %v, %v, got %v, %v",
+					group, key, expected, expectedExists, value, exists)
+			}
+		}
+	}
+	return nil
+}
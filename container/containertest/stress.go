@@ -0,0 +1,388 @@
+package containertest
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/container"
+	"github.com/aeimer/go-multikeymap/multikeymap"
+)
+
+// stressWorkers is the number of goroutines of the stress suites.
+const stressWorkers = 16
+
+// stressOperations is the number of operations of every goroutine of the stress suites.
+const stressOperations = 500
+
+// ConcurrentMultiKeyMap is the behavior of multikeymap.ConcurrentMultiKeyMap which is checked by TestConcurrentMultiKeyMap.
+// Besides the MultiKeyMap behavior, it contains the methods which read many entries,
+// so the stress suite also detects if one of them reads without synchronization.
+type ConcurrentMultiKeyMap[K comparable, V any] interface {
+	MultiKeyMap[K, V]
+	container.KeyedContainer[K, V]
+	Groups() []string
+	GroupSize(group string) int
+	KeysInGroup(group string) iter.Seq[string]
+	SecondaryKeysOf(primaryKey K) map[string][]string
+	GroupBy(group string) map[string][]V
+	Query(q multikeymap.Query) iter.Seq2[K, V]
+	Page(cursor multikeymap.Cursor, limit int) ([]multikeymap.Entry[K, V], multikeymap.Cursor, error)
+	GroupPage(group string, cursor multikeymap.Cursor, limit int) ([]multikeymap.GroupEntry[K, V], multikeymap.Cursor, error)
+}
+
+// ConcurrentBiKeyMap is the behavior of bikeymap.ConcurrentBiKeyMap which is checked by TestConcurrentBiKeyMap.
+// Besides the BiKeyMap behavior, it contains the methods which read many entries or the other key,
+// so the stress suite also detects if one of them reads without synchronization.
+type ConcurrentBiKeyMap[KeyA comparable, KeyB comparable, V any] interface {
+	BiKeyMap[KeyA, KeyB, V]
+	container.KeyedContainer[KeyA, V]
+	KeyBForKeyA(keyA KeyA) (KeyB, bool)
+	KeyAForKeyB(keyB KeyB) (KeyA, bool)
+	HasKeyA(keyA KeyA) bool
+	HasKeyB(keyB KeyB) bool
+	KeysA() []KeyA
+	KeysB() []KeyB
+}
+
+// TestConcurrentMultiKeyMap runs the stress suite for maps with the MultiKeyMap behavior
+// which are safe for concurrent use. It should be run with the race detector,
+// which reports methods that access the map without synchronization.
+// The function newMap must return a new empty map.
+//
+// Every goroutine applies random operations to its own primary and secondary keys
+// and compares them with its own reference implementation after every operation.
+// In between, it calls the methods which read the whole map and checks that they never see
+// a secondary key of one goroutine pointing to a primary key of another goroutine.
+func TestConcurrentMultiKeyMap(t *testing.T, newMap func() ConcurrentMultiKeyMap[string, int]) {
+	t.Helper()
+
+	t.Run("Disjoint", func(t *testing.T) {
+		m := newMap()
+		models := make([]*multiKeyMapModel, stressWorkers)
+		runStress(t, func(worker int) error {
+			models[worker] = newMultiKeyMapModel()
+			return runConcurrentMultiKeyMapOperations(m, models[worker], worker)
+		})
+		if t.Failed() {
+			return
+		}
+
+		merged := newMultiKeyMapModel()
+		for _, model := range models {
+			maps.Copy(merged.values, model.values)
+			for group, keys := range model.secondary {
+				for key, primaryKey := range keys {
+					merged.putSecondaryKey(primaryKey, group, key)
+				}
+			}
+		}
+		if m.Size() != len(merged.values) {
+			t.Fatalf("expected size %d, got %d", len(merged.values), m.Size())
+		}
+		if err := sameElements(slices.Collect(maps.Values(merged.values)), m.Values()); err != nil {
+			t.Fatal(err)
+		}
+		if groups := m.GetAllKeyGroups(); !maps.EqualFunc(merged.secondary, groups, maps.Equal) {
+			t.Fatalf("GetAllKeyGroups: expected groups %v, got %v", sortedKeys(merged.secondary), sortedKeys(groups))
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		m := newMap()
+		runStress(t, func(worker int) error {
+			rng := rand.New(rand.NewPCG(uint64(worker), 0))
+			for range stressOperations {
+				primaryKey := multiKeyMapPrimaryKeys[rng.IntN(len(multiKeyMapPrimaryKeys))]
+				switch op := rng.IntN(10); {
+				case op < 5:
+					m.Put(primaryKey, rng.IntN(100))
+				case op < 8:
+					m.PutSecondaryKeys(primaryKey, multiKeyMapGroups[0], multiKeyMapKeys[rng.IntN(len(multiKeyMapKeys))])
+				case op < 9:
+					m.Clear()
+				default:
+					if size := m.Size(); size > len(multiKeyMapPrimaryKeys) {
+						return fmt.Errorf("expected at most %d elements, got %d", len(multiKeyMapPrimaryKeys), size)
+					}
+					if err := readMultiKeyMap(m, primaryKey); err != nil {
+						return err
+					}
+				}
+			}
+			return nil
+		})
+
+		m.Clear()
+		if !m.Empty() || m.Size() != 0 || len(m.Values()) != 0 || len(m.GetAllKeyGroups()) != 0 {
+			t.Errorf("expected map to be empty after Clear, got %v", m)
+		}
+	})
+}
+
+// runConcurrentMultiKeyMapOperations applies random operations to the keys of one goroutine.
+func runConcurrentMultiKeyMapOperations(m ConcurrentMultiKeyMap[string, int], model *multiKeyMapModel, worker int) error {
+	rng := rand.New(rand.NewPCG(uint64(worker), 0))
+	prefix := fmt.Sprintf("worker%d-", worker)
+	primaryKeys := prefixed(prefix, multiKeyMapPrimaryKeys)
+	keys := prefixed(prefix, multiKeyMapKeys)
+	for i := range stressOperations {
+		primaryKey := primaryKeys[rng.IntN(len(primaryKeys))]
+		group := multiKeyMapGroups[rng.IntN(len(multiKeyMapGroups))]
+		key := keys[rng.IntN(len(keys))]
+
+		var operation string
+		switch op := rng.IntN(20); {
+		case op < 7:
+			value := rng.IntN(100)
+			operation = fmt.Sprintf("Put(%q, %d)", primaryKey, value)
+			m.Put(primaryKey, value)
+			model.put(primaryKey, value)
+		case op < 12:
+			operation = fmt.Sprintf("PutSecondaryKeys(%q, %q, %q)", primaryKey, group, key)
+			m.PutSecondaryKeys(primaryKey, group, key)
+			model.putSecondaryKey(primaryKey, group, key)
+		case op < 16:
+			operation = fmt.Sprintf("Remove(%q)", primaryKey)
+			m.Remove(primaryKey)
+			model.remove(primaryKey)
+		default:
+			operation = "reading the whole map"
+			if err := checkMultiKeyMapSnapshot(m, primaryKey); err != nil {
+				return fmt.Errorf("operation %d %s: %w", i, operation, err)
+			}
+		}
+
+		if err := model.compareKeys(m, primaryKeys, keys); err != nil {
+			return fmt.Errorf("after operation %d %s: %w", i, operation, err)
+		}
+	}
+	return nil
+}
+
+// checkMultiKeyMapSnapshot calls the methods which read the whole map and checks the invariants,
+// which hold no matter which operations other goroutines apply.
+func checkMultiKeyMapSnapshot(m ConcurrentMultiKeyMap[string, int], primaryKey string) error {
+	maxSize := stressWorkers * len(multiKeyMapPrimaryKeys)
+	if size := m.Size(); size < 0 || size > maxSize {
+		return fmt.Errorf("expected size between 0 and %d, got %d", maxSize, size)
+	}
+	if values := m.Values(); len(values) > maxSize {
+		return fmt.Errorf("expected at most %d values, got %d", maxSize, len(values))
+	}
+	if keys := m.Keys(); len(keys) > maxSize {
+		return fmt.Errorf("expected at most %d keys, got %d", maxSize, len(keys))
+	}
+	if err := readMultiKeyMap(m, primaryKey); err != nil {
+		return err
+	}
+	for group, keys := range m.GetAllKeyGroups() {
+		for key, primaryKey := range keys {
+			if keyWorker, _, _ := strings.Cut(key, "-"); !strings.HasPrefix(primaryKey, keyWorker+"-") {
+				return fmt.Errorf("secondary key %q in group %q points to primary key %q of another goroutine",
+					key, group, primaryKey)
+			}
+		}
+	}
+	return nil
+}
+
+// readMultiKeyMap calls all methods which read many entries of the map.
+// Their results are not compared, as other goroutines may modify the map in between.
+func readMultiKeyMap(m ConcurrentMultiKeyMap[string, int], primaryKey string) error {
+	_ = m.Empty()
+	_ = m.String()
+	_ = m.Has(primaryKey)
+	for range m.All() {
+	}
+	for _, group := range m.Groups() {
+		_ = m.GroupSize(group)
+		for key := range m.KeysInGroup(group) {
+			for range m.Query(multikeymap.Eq(group, key)) {
+			}
+		}
+		_ = m.GroupBy(group)
+		if _, _, err := m.GroupPage(group, "", 2); err != nil {
+			return fmt.Errorf("GroupPage(%q): %w", group, err)
+		}
+	}
+	_ = m.SecondaryKeysOf(primaryKey)
+	if _, _, err := m.Page("", 2); err != nil {
+		return fmt.Errorf("Page: %w", err)
+	}
+	return nil
+}
+
+// TestConcurrentBiKeyMap runs the stress suite for maps with the BiKeyMap behavior
+// which are safe for concurrent use. It should be run with the race detector,
+// which reports methods that access the map without synchronization.
+// The function newMap must return a new empty map.
+//
+// Every goroutine applies random operations to its own keys
+// and compares them with its own reference implementation after every operation.
+// In between, it calls the methods which read the whole map.
+func TestConcurrentBiKeyMap(t *testing.T, newMap func() ConcurrentBiKeyMap[string, int, int]) {
+	t.Helper()
+
+	t.Run("Disjoint", func(t *testing.T) {
+		m := newMap()
+		models := make([]*biKeyMapModel, stressWorkers)
+		runStress(t, func(worker int) error {
+			models[worker] = newBiKeyMapModel()
+			return runConcurrentBiKeyMapOperations(m, models[worker], worker)
+		})
+		if t.Failed() {
+			return
+		}
+
+		merged := newBiKeyMapModel()
+		for _, model := range models {
+			maps.Copy(merged.values, model.values)
+			maps.Copy(merged.keyBByKeyA, model.keyBByKeyA)
+		}
+		if m.Size() != len(merged.values) {
+			t.Fatalf("expected size %d, got %d", len(merged.values), m.Size())
+		}
+		if err := sameElements(slices.Collect(maps.Values(merged.values)), m.Values()); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		m := newMap()
+		runStress(t, func(worker int) error {
+			rng := rand.New(rand.NewPCG(uint64(worker), 0))
+			for range stressOperations {
+				// The keys are always paired the same way, so Put never conflicts.
+				n := rng.IntN(len(biKeyMapKeysA))
+				switch op := rng.IntN(10); {
+				case op < 5:
+					if err := m.Put(biKeyMapKeysA[n], biKeyMapKeysB[n], rng.IntN(100)); err != nil {
+						return fmt.Errorf("Put(%q, %d): %w", biKeyMapKeysA[n], biKeyMapKeysB[n], err)
+					}
+				case op < 7:
+					_ = m.RemoveByKeyB(biKeyMapKeysB[n])
+				case op < 8:
+					m.Clear()
+				default:
+					if size := m.Size(); size > len(biKeyMapKeysA) {
+						return fmt.Errorf("expected at most %d elements, got %d", len(biKeyMapKeysA), size)
+					}
+					_ = m.Values()
+					readBiKeyMap(m, biKeyMapKeysA[n], biKeyMapKeysB[n])
+				}
+			}
+			return nil
+		})
+
+		m.Clear()
+		if !m.Empty() || m.Size() != 0 || len(m.Values()) != 0 {
+			t.Errorf("expected map to be empty after Clear, got %v", m)
+		}
+	})
+}
+
+// runConcurrentBiKeyMapOperations applies random operations to the keys of one goroutine.
+func runConcurrentBiKeyMapOperations(m ConcurrentBiKeyMap[string, int, int], model *biKeyMapModel, worker int) error {
+	rng := rand.New(rand.NewPCG(uint64(worker), 0))
+	keysA := prefixed(fmt.Sprintf("worker%d-", worker), biKeyMapKeysA)
+	keysB := make([]int, 0, len(biKeyMapKeysB))
+	for _, keyB := range biKeyMapKeysB {
+		keysB = append(keysB, worker*len(biKeyMapKeysB)+keyB)
+	}
+	maxSize := stressWorkers * len(biKeyMapKeysA)
+	for i := range stressOperations {
+		keyA := keysA[rng.IntN(len(keysA))]
+		keyB := keysB[rng.IntN(len(keysB))]
+
+		var operation string
+		var err, expectedErr error
+		switch op := rng.IntN(20); {
+		case op < 8:
+			value := rng.IntN(100)
+			operation = fmt.Sprintf("Put(%q, %d, %d)", keyA, keyB, value)
+			err, expectedErr = m.Put(keyA, keyB, value), model.put(keyA, keyB, value)
+		case op < 11:
+			operation = fmt.Sprintf("RemoveByKeyA(%q)", keyA)
+			err, expectedErr = m.RemoveByKeyA(keyA), model.removeByKeyA(keyA)
+		case op < 14:
+			operation = fmt.Sprintf("RemoveByKeyB(%d)", keyB)
+			err, expectedErr = m.RemoveByKeyB(keyB), model.removeByKeyB(keyB)
+		default:
+			operation = "reading the whole map"
+			if size := m.Size(); size < 0 || size > maxSize {
+				return fmt.Errorf("operation %d %s: expected size between 0 and %d, got %d", i, operation, maxSize, size)
+			}
+			if values := m.Values(); len(values) > maxSize {
+				return fmt.Errorf("operation %d %s: expected at most %d values, got %d", i, operation, maxSize, len(values))
+			}
+			if keys := m.KeysB(); len(keys) > maxSize {
+				return fmt.Errorf("operation %d %s: expected at most %d keys, got %d", i, operation, maxSize, len(keys))
+			}
+			readBiKeyMap(m, keyA, keyB)
+		}
+
+		if (err == nil) != (expectedErr == nil) {
+			return fmt.Errorf("operation %d %s: expected error %v, got %v", i, operation, expectedErr, err)
+		}
+		if err := model.compareKeys(m, keysA, keysB); err != nil {
+			return fmt.Errorf("after operation %d %s: %w", i, operation, err)
+		}
+	}
+	return nil
+}
+
+// readBiKeyMap calls all methods which read many entries or the other key of an entry.
+// Their results are not compared, as other goroutines may modify the map in between.
+func readBiKeyMap(m ConcurrentBiKeyMap[string, int, int], keyA string, keyB int) {
+	_ = m.Empty()
+	_ = m.String()
+	_ = m.Keys()
+	_ = m.KeysA()
+	_ = m.KeysB()
+	_ = m.Has(keyA)
+	_ = m.HasKeyA(keyA)
+	_ = m.HasKeyB(keyB)
+	_, _ = m.Get(keyA)
+	_, _ = m.KeyBForKeyA(keyA)
+	_, _ = m.KeyAForKeyB(keyB)
+	for range m.All() {
+	}
+}
+
+// runStress runs the function concurrently in stressWorkers goroutines and reports all returned errors.
+func runStress(t *testing.T, run func(worker int) error) {
+	t.Helper()
+
+	errs := make([]error, stressWorkers)
+	var wg sync.WaitGroup
+	for worker := range stressWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := run(worker); err != nil {
+				errs[worker] = fmt.Errorf("goroutine %d: %w", worker, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		t.Error(err)
+	}
+}
+
+// prefixed returns the keys with the prefix prepended.
+func prefixed(prefix string, keys []string) []string {
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, prefix+key)
+	}
+	return result
+}
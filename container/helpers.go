@@ -0,0 +1,45 @@
+package container
+
+import "iter"
+
+// Filter returns an iterator over the entries of the container for which keep returns true.
+func Filter[K any, V any](c KeyedContainer[K, V], keep func(key K, value V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, value := range c.All() {
+			if keep(key, value) && !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// MapValues returns an iterator over the entries of the container with the values converted by f.
+func MapValues[K any, V any, R any](c KeyedContainer[K, V], f func(value V) R) iter.Seq2[K, R] {
+	return func(yield func(K, R) bool) {
+		for key, value := range c.All() {
+			if !yield(key, f(value)) {
+				return
+			}
+		}
+	}
+}
+
+// Collect returns a native map with all entries of the container.
+func Collect[K comparable, V any](c KeyedContainer[K, V]) map[K]V {
+	result := make(map[K]V, c.Size())
+	for key, value := range c.All() {
+		result[key] = value
+	}
+	return result
+}
+
+// Count returns the number of entries of the container for which match returns true.
+func Count[K any, V any](c KeyedContainer[K, V], match func(key K, value V) bool) int {
+	count := 0
+	for key, value := range c.All() {
+		if match(key, value) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,59 @@
+package container_test
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/container"
+	"github.com/stretchr/testify/assert"
+)
+
+// mapContainer is a minimal container.KeyedContainer based on a native map.
+type mapContainer map[string]int
+
+func (c mapContainer) Empty() bool                 { return len(c) == 0 }
+func (c mapContainer) Size() int                   { return len(c) }
+func (c mapContainer) Values() []int               { return slices.Collect(maps.Values(c)) }
+func (c mapContainer) Clear()                      { clear(c) }
+func (c mapContainer) String() string              { return fmt.Sprintf("mapContainer: %v", map[string]int(c)) }
+func (c mapContainer) Keys() []string              { return slices.Collect(maps.Keys(c)) }
+func (c mapContainer) Has(key string) bool         { _, exists := c[key]; return exists }
+func (c mapContainer) Get(key string) (int, bool)  { value, exists := c[key]; return value, exists }
+func (c mapContainer) All() iter.Seq2[string, int] { return maps.All(c) }
+
+func newMapContainer() container.KeyedContainer[string, int] {
+	return mapContainer{"one": 1, "two": 2, "three": 3, "four": 4}
+}
+
+func isEven(_ string, value int) bool {
+	return value%2 == 0
+}
+
+func TestFilter(t *testing.T) {
+	assert.Equal(t, map[string]int{"two": 2, "four": 4}, maps.Collect(container.Filter(newMapContainer(), isEven)))
+
+	// Stopping the iteration early must be possible.
+	for range container.Filter(newMapContainer(), isEven) {
+		break
+	}
+}
+
+func TestMapValues(t *testing.T) {
+	doubled := container.MapValues(newMapContainer(), func(value int) string {
+		return fmt.Sprint(value * 2)
+	})
+	assert.Equal(t, map[string]string{"one": "2", "two": "4", "three": "6", "four": "8"}, maps.Collect(doubled))
+}
+
+func TestCollect(t *testing.T) {
+	assert.Equal(t, map[string]int{"one": 1, "two": 2, "three": 3, "four": 4}, container.Collect(newMapContainer()))
+	assert.Empty(t, container.Collect[string, int](mapContainer{}))
+}
+
+func TestCount(t *testing.T) {
+	assert.Equal(t, 2, container.Count(newMapContainer(), isEven))
+	assert.Equal(t, 0, container.Count[string, int](mapContainer{}, isEven))
+}
@@ -0,0 +1,75 @@
+package container
+
+import "iter"
+
+// Pair is the value of a left entry of a join together with the value of the matching right entry.
+type Pair[L any, R any] struct {
+	Left  L
+	Right R
+	// Matched is false if LeftJoin found no right entry. Right is the zero value then.
+	Matched bool
+}
+
+// SecondaryKeyed is implemented by the maps with secondary keys in groups, e.g. multikeymap.MultiKeyMap.
+type SecondaryKeyed[V any] interface {
+	KeysInGroup(group string) iter.Seq[string]
+	GetBySecondaryKey(group string, key string) (V, bool)
+}
+
+// InnerJoin returns an iterator over the left entries for which right finds a value, paired with that value.
+// The left entries are usually the All iterator of a container and right its Get method or BySecondaryKey.
+// For a BiKeyMap, the All iterator of its Inverse joins on KeyB.
+// The join is lazy: right is only called while iterating.
+func InnerJoin[K any, L any, R any](left iter.Seq2[K, L], right func(key K) (R, bool)) iter.Seq2[K, Pair[L, R]] {
+	return func(yield func(K, Pair[L, R]) bool) {
+		for key, value := range left {
+			if match, exists := right(key); exists && !yield(key, Pair[L, R]{Left: value, Right: match, Matched: true}) {
+				return
+			}
+		}
+	}
+}
+
+// LeftJoin returns an iterator over all left entries, paired with the value right finds for them.
+// Entries without a match are paired with the zero value and Matched set to false.
+func LeftJoin[K any, L any, R any](left iter.Seq2[K, L], right func(key K) (R, bool)) iter.Seq2[K, Pair[L, R]] {
+	return func(yield func(K, Pair[L, R]) bool) {
+		for key, value := range left {
+			match, exists := right(key)
+			if !yield(key, Pair[L, R]{Left: value, Right: match, Matched: exists}) {
+				return
+			}
+		}
+	}
+}
+
+// AntiJoin returns an iterator over the left entries for which right finds no value.
+func AntiJoin[K any, L any, R any](left iter.Seq2[K, L], right func(key K) (R, bool)) iter.Seq2[K, L] {
+	return func(yield func(K, L) bool) {
+		for key, value := range left {
+			if _, exists := right(key); !exists && !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// Group returns an iterator over the secondary keys of a group and the values they point to.
+// It is used as the left side of a join on a secondary group.
+func Group[V any](m SecondaryKeyed[V], group string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		for key := range m.KeysInGroup(group) {
+			if value, exists := m.GetBySecondaryKey(group, key); exists && !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// BySecondaryKey returns a function which looks up a value by a secondary key of the group.
+// It is used as the right side of a join on a secondary group.
+func BySecondaryKey[V any](m SecondaryKeyed[V], group string) func(key string) (V, bool) {
+	return func(key string) (V, bool) {
+		return m.GetBySecondaryKey(group, key)
+	}
+}
@@ -0,0 +1,128 @@
+package container_test
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/bikeymap"
+	"github.com/aeimer/go-multikeymap/container"
+	"github.com/aeimer/go-multikeymap/multikeymap"
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleInnerJoin() {
+	type Product struct {
+		Name string
+	}
+	products := multikeymap.New[string, Product](multikeymap.WithInsertionOrder())
+	products.Put("p1", Product{"Chair"})
+	products.Put("p2", Product{"Table"})
+	products.Put("p3", Product{"Lamp"})
+	products.PutSecondaryKeys("p1", "sku", "SKU-1")
+	products.PutSecondaryKeys("p2", "sku", "SKU-2")
+	products.PutSecondaryKeys("p3", "sku", "SKU-3")
+
+	// Prices by ID and SKU.
+	prices := bikeymap.New[int, string, float64](bikeymap.WithInsertionOrder())
+	_ = prices.Put(1, "SKU-1", 49.90)
+	_ = prices.Put(2, "SKU-2", 199.00)
+
+	// Join KeyB of the prices to the sku group of the products.
+	for sku, pair := range container.InnerJoin(prices.Inverse().All(), container.BySecondaryKey(products, "sku")) {
+		fmt.Printf("%s: %s %.2f\n", sku, pair.Right.Name, pair.Left)
+	}
+	// Products whose SKU has no price.
+	for sku, product := range container.AntiJoin(container.Group(products, "sku"), prices.GetByKeyB) {
+		fmt.Printf("%s: %s without price\n", sku, product.Name)
+	}
+
+	// Output:
+	// SKU-1: Chair 49.90
+	// SKU-2: Table 199.00
+	// SKU-3: Lamp without price
+}
+
+func newStockContainer() container.KeyedContainer[string, int] {
+	return mapContainer{"two": 20, "four": 40, "five": 50}
+}
+
+func TestInnerJoin(t *testing.T) {
+	joined := container.InnerJoin(newMapContainer().All(), newStockContainer().Get)
+	assert.Equal(t, map[string]container.Pair[int, int]{
+		"two":  {Left: 2, Right: 20, Matched: true},
+		"four": {Left: 4, Right: 40, Matched: true},
+	}, maps.Collect(joined))
+	for range joined {
+		break
+	}
+}
+
+func TestLeftJoin(t *testing.T) {
+	joined := container.LeftJoin(newMapContainer().All(), newStockContainer().Get)
+	assert.Equal(t, map[string]container.Pair[int, int]{
+		"one":   {Left: 1},
+		"two":   {Left: 2, Right: 20, Matched: true},
+		"three": {Left: 3},
+		"four":  {Left: 4, Right: 40, Matched: true},
+	}, maps.Collect(joined))
+	for range joined {
+		break
+	}
+}
+
+func TestAntiJoin(t *testing.T) {
+	joined := container.AntiJoin(newMapContainer().All(), newStockContainer().Get)
+	assert.Equal(t, map[string]int{"one": 1, "three": 3}, maps.Collect(joined))
+	assert.Empty(t, maps.Collect(container.AntiJoin(newMapContainer().All(), newMapContainer().Get)))
+	for range joined {
+		break
+	}
+}
+
+func TestJoin_Lazy(t *testing.T) {
+	calls := 0
+	right := func(key string) (int, bool) {
+		calls++
+		return 0, true
+	}
+	joined := container.InnerJoin(newMapContainer().All(), right)
+	assert.Equal(t, 0, calls)
+	for range joined {
+		break
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestJoin_SecondaryGroups(t *testing.T) {
+	mm := multikeymap.New[string, int]()
+	mm.Put("key1", 1)
+	mm.Put("key2", 2)
+	mm.PutSecondaryKeys("key1", "group1", "one", "uno")
+	mm.PutSecondaryKeys("key2", "group1", "two")
+	mm.PutSecondaryKeys("key3", "group1", "three")
+
+	assert.Equal(t, map[string]int{"one": 1, "uno": 1, "two": 2}, maps.Collect(container.Group(mm, "group1")))
+	for range container.Group(mm, "group1") {
+		break
+	}
+
+	// Secondary group to primary key.
+	assert.Equal(t, map[string]container.Pair[int, int]{
+		"one": {Left: 1, Right: 1, Matched: true},
+		"two": {Left: 2, Right: 2, Matched: true},
+	}, maps.Collect(container.InnerJoin(container.Group(mm, "group1"), newMapContainer().Get)))
+
+	// Primary key to secondary group.
+	assert.Equal(t, map[string]int{"three": 3, "four": 4}, maps.Collect(
+		container.AntiJoin(newMapContainer().All(), container.BySecondaryKey(mm, "group1"))))
+
+	// KeyB of a BiKeyMap to a secondary group.
+	bm := bikeymap.NewConcurrent[int, string, string]()
+	_ = bm.Put(1, "one", "a")
+	_ = bm.Put(3, "three", "c")
+	assert.Equal(t, map[string]container.Pair[string, int]{
+		"one":   {Left: "a", Right: 1, Matched: true},
+		"three": {Left: "c"},
+	}, maps.Collect(container.LeftJoin(bm.Inverse().All(), container.BySecondaryKey(mm, "group1"))))
+}
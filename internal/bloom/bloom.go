@@ -0,0 +1,147 @@
+// Package bloom provides a bloom filter which follows a changing set of keys.
+//
+// Keys cannot be deleted from a bloom filter, so removed keys stay set until the filter is rebuilt.
+// NeedsRebuild reports when the filter is full or too many of its keys were removed,
+// and Rebuild sizes it for the current keys again. Both happen after O(n) changes,
+// so keeping the filter in sync is amortized O(1) per change.
+package bloom
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"reflect"
+	"unsafe"
+)
+
+const (
+	// bitsPerKey and hashCount give a false positive rate of about 1% for a full filter.
+	bitsPerKey = 10
+	hashCount  = 7
+	// minCapacity is the smallest number of keys a filter is sized for.
+	minCapacity = 64
+)
+
+// Filter is a bloom filter over keys of type K.
+// MayContain never returns false for a key which was added since the last rebuild.
+type Filter[K any] struct {
+	hash     func(K) uint64
+	words    []uint64
+	mask     uint64 // number of bits minus one, which is a power of two
+	capacity int    // number of keys the words are sized for
+	added    int    // keys added since the last rebuild, including removed ones
+	removed  int    // removed keys which are still set in words
+}
+
+// New creates an empty filter which uses hash for the keys.
+func New[K any](hash func(K) uint64) *Filter[K] {
+	f := &Filter[K]{hash: hash}
+	f.reset(0)
+	return f
+}
+
+// reset clears the filter and sizes it for twice the given number of keys.
+func (f *Filter[K]) reset(size int) {
+	f.capacity = max(minCapacity, 2*size)
+	// A power of two as the number of bits replaces the modulo with a mask.
+	n := 1 << bits.Len(uint((f.capacity*bitsPerKey+63)/64-1))
+	f.words = make([]uint64, n)
+	f.mask = uint64(n*64 - 1)
+	f.added = 0
+	f.removed = 0
+}
+
+// Add sets the bits of a key.
+func (f *Filter[K]) Add(key K) {
+	h1, h2 := f.hashes(key)
+	for i := range uint64(hashCount) {
+		bit := (h1 + i*h2) & f.mask
+		f.words[bit/64] |= 1 << (bit % 64)
+	}
+	f.added++
+}
+
+// Remove records that one of the added keys was removed.
+// Its bits stay set, so MayContain may still return true for it until the next rebuild.
+func (f *Filter[K]) Remove() {
+	f.removed++
+}
+
+// MayContain returns false if the key was definitely not added.
+func (f *Filter[K]) MayContain(key K) bool {
+	h1, h2 := f.hashes(key)
+	for i := range uint64(hashCount) {
+		bit := (h1 + i*h2) & f.mask
+		if f.words[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hashes derives the two hashes for double hashing from the hash of the key.
+func (f *Filter[K]) hashes(key K) (uint64, uint64) {
+	h := f.hash(key)
+	return h & 0xffffffff, h>>32 | 1
+}
+
+// NeedsRebuild reports if more keys were added than the filter is sized for,
+// or if so many keys were removed that rebuilding lowers the false positive rate noticeably.
+func (f *Filter[K]) NeedsRebuild() bool {
+	return f.added > f.capacity || f.removed > f.capacity/4
+}
+
+// Rebuild clears the filter, sizes it for size keys and adds the keys.
+func (f *Filter[K]) Rebuild(keys iter.Seq[K], size int) {
+	f.reset(size)
+	for key := range keys {
+		f.Add(key)
+	}
+}
+
+// HashFor returns a hash function for keys of type K, or nil if K is neither a string nor an integer type.
+// The kind of K is looked up once, so keys of named string and integer types are hashed without reflection.
+func HashFor[K comparable]() func(K) uint64 {
+	seed := maphash.MakeSeed()
+	t := reflect.TypeFor[K]()
+	switch t.Kind() {
+	case reflect.String:
+		return func(key K) uint64 { return maphash.String(seed, *(*string)(unsafe.Pointer(&key))) }
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return intHash[K](t.Size(), true)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return intHash[K](t.Size(), false)
+	}
+	return nil
+}
+
+// intHash returns a hash function for an integer type K with the given size in bytes.
+// It reads the key as the integer type of the same size, signed keys are sign-extended.
+func intHash[K comparable](size uintptr, signed bool) func(K) uint64 {
+	switch {
+	case size == 1 && signed:
+		return func(key K) uint64 { return mix(uint64(*(*int8)(unsafe.Pointer(&key)))) }
+	case size == 2 && signed:
+		return func(key K) uint64 { return mix(uint64(*(*int16)(unsafe.Pointer(&key)))) }
+	case size == 4 && signed:
+		return func(key K) uint64 { return mix(uint64(*(*int32)(unsafe.Pointer(&key)))) }
+	case size == 1:
+		return func(key K) uint64 { return mix(uint64(*(*uint8)(unsafe.Pointer(&key)))) }
+	case size == 2:
+		return func(key K) uint64 { return mix(uint64(*(*uint16)(unsafe.Pointer(&key)))) }
+	case size == 4:
+		return func(key K) uint64 { return mix(uint64(*(*uint32)(unsafe.Pointer(&key)))) }
+	default:
+		return func(key K) uint64 { return mix(*(*uint64)(unsafe.Pointer(&key))) }
+	}
+}
+
+// mix spreads the bits of an integer key over the whole hash (the finalizer of SplitMix64).
+func mix(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,112 @@
+package bloom
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilter(t *testing.T) {
+	f := New(HashFor[string]())
+	const size = 10_000
+	for n := range size {
+		f.Add(strconv.Itoa(n))
+		if f.NeedsRebuild() {
+			f.Rebuild(func(yield func(string) bool) {
+				for m := range n + 1 {
+					if !yield(strconv.Itoa(m)) {
+						return
+					}
+				}
+			}, n+1)
+		}
+	}
+	for n := range size {
+		require.True(t, f.MayContain(strconv.Itoa(n)))
+	}
+
+	falsePositives := 0
+	for n := size; n < 2*size; n++ {
+		if f.MayContain(strconv.Itoa(n)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, falsePositives, size/50)
+}
+
+func TestFilter_NeedsRebuild(t *testing.T) {
+	f := New(HashFor[int]())
+	for n := range minCapacity {
+		f.Add(n)
+	}
+	assert.False(t, f.NeedsRebuild())
+	f.Add(minCapacity)
+	assert.True(t, f.NeedsRebuild())
+
+	f.Rebuild(slices.Values([]int{1, 2, 3}), 3)
+	assert.False(t, f.NeedsRebuild())
+	for range minCapacity / 4 {
+		f.Remove()
+	}
+	assert.False(t, f.NeedsRebuild())
+	f.Remove()
+	assert.True(t, f.NeedsRebuild())
+
+	f.Rebuild(slices.Values([]int{1}), 1)
+	assert.True(t, f.MayContain(1))
+	assert.False(t, f.NeedsRebuild())
+}
+
+func TestHashFor(t *testing.T) {
+	type name string
+	type id uint16
+	assert.NotNil(t, HashFor[string]())
+	assert.NotNil(t, HashFor[int]())
+	assert.NotNil(t, HashFor[int64]())
+	assert.NotNil(t, HashFor[uint64]())
+	assert.NotNil(t, HashFor[int32]())
+	assert.NotNil(t, HashFor[uintptr]())
+	assert.Nil(t, HashFor[float64]())
+	assert.Nil(t, HashFor[struct{ a int }]())
+
+	hashName := HashFor[name]()
+	assert.Equal(t, hashName("a"), hashName("a"))
+	assert.NotEqual(t, hashName("a"), hashName("b"))
+	hashID := HashFor[id]()
+	assert.Equal(t, hashID(1), hashID(1))
+	assert.NotEqual(t, hashID(1), hashID(2))
+
+	// Signed keys hash like their value converted to int64.
+	assert.Equal(t, HashFor[int64]()(-3), HashFor[int8]()(-3))
+	assert.Equal(t, HashFor[uint64]()(3), HashFor[uint16]()(3))
+}
+
+func TestHashFor_NoAllocs(t *testing.T) {
+	type name string
+	type id int32
+	hashName := HashFor[name]()
+	hashID := HashFor[id]()
+	keyName, keyID := name("key"), id(-1)
+	assert.Zero(t, testing.AllocsPerRun(100, func() { hashName(keyName) }))
+	assert.Zero(t, testing.AllocsPerRun(100, func() { hashID(keyID) }))
+}
+
+func BenchmarkFilterMayContain(b *testing.B) {
+	f := New(HashFor[string]())
+	keys := make([]string, 100_000)
+	for n := range keys {
+		keys[n] = strconv.Itoa(n)
+	}
+	f.Rebuild(slices.Values(keys), len(keys))
+	misses := make([]string, len(keys))
+	for n := range misses {
+		misses[n] = keys[n] + "x"
+	}
+	b.ResetTimer()
+	for n := range b.N {
+		f.MayContain(misses[n%len(misses)])
+	}
+}
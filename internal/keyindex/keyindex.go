@@ -0,0 +1,96 @@
+// Package keyindex assigns comparable IDs to keys of any type.
+// Keys are compared with a user-supplied hash and equality function,
+// keys with the same hash are stored in the same bucket.
+package keyindex
+
+// ID identifies a key of an Index. IDs are never reused by the same Index.
+type ID uint64
+
+// Index assigns IDs to keys. It is not safe for concurrent use.
+type Index[K any] struct {
+	hash    func(K) uint64
+	equal   func(K, K) bool
+	buckets map[uint64][]entry[K]
+	keys    map[ID]K
+	nextID  ID
+}
+
+type entry[K any] struct {
+	key K
+	id  ID
+}
+
+// New creates a new Index. Keys which are equal must have the same hash.
+func New[K any](hash func(K) uint64, equal func(K, K) bool) *Index[K] {
+	return &Index[K]{
+		hash:    hash,
+		equal:   equal,
+		buckets: make(map[uint64][]entry[K]),
+		keys:    make(map[ID]K),
+	}
+}
+
+// Lookup returns the ID of the key.
+func (x *Index[K]) Lookup(key K) (ID, bool) {
+	for _, e := range x.buckets[x.hash(key)] {
+		if x.equal(e.key, key) {
+			return e.id, true
+		}
+	}
+	return 0, false
+}
+
+// Add returns the ID of the key and assigns a new ID if the key does not exist.
+// It reports whether the ID is new.
+func (x *Index[K]) Add(key K) (ID, bool) {
+	if id, exists := x.Lookup(key); exists {
+		return id, false
+	}
+	id := x.nextID
+	x.nextID++
+	hash := x.hash(key)
+	x.buckets[hash] = append(x.buckets[hash], entry[K]{key: key, id: id})
+	x.keys[id] = key
+	return id, true
+}
+
+// Key returns the key of the ID.
+func (x *Index[K]) Key(id ID) K {
+	return x.keys[id]
+}
+
+// Delete removes the ID and its key.
+func (x *Index[K]) Delete(id ID) {
+	key, exists := x.keys[id]
+	if !exists {
+		return
+	}
+	delete(x.keys, id)
+	hash := x.hash(key)
+	bucket := x.buckets[hash]
+	for i, e := range bucket {
+		if e.id == id {
+			last := len(bucket) - 1
+			bucket[i] = bucket[last]
+			bucket[last] = entry[K]{}
+			bucket = bucket[:last]
+			break
+		}
+	}
+	if len(bucket) == 0 {
+		delete(x.buckets, hash)
+	} else {
+		x.buckets[hash] = bucket
+	}
+}
+
+// Len returns the number of keys.
+func (x *Index[K]) Len() int {
+	return len(x.keys)
+}
+
+// Clear removes all keys.
+func (x *Index[K]) Clear() {
+	x.buckets = make(map[uint64][]entry[K])
+	x.keys = make(map[ID]K)
+}
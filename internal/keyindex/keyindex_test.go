@@ -0,0 +1,59 @@
+package keyindex
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// collidingHash puts all keys into the same bucket.
+func collidingHash([]byte) uint64 {
+	return 42
+}
+
+func TestIndex(t *testing.T) {
+	x := New(collidingHash, bytes.Equal)
+	id1, added := x.Add([]byte("key1"))
+	assert.True(t, added)
+	id2, added := x.Add([]byte("key2"))
+	assert.True(t, added)
+	assert.NotEqual(t, id1, id2)
+
+	id, added := x.Add([]byte("key1"))
+	assert.False(t, added)
+	assert.Equal(t, id1, id)
+	id, exists := x.Lookup([]byte("key2"))
+	assert.True(t, exists)
+	assert.Equal(t, id2, id)
+	assert.Equal(t, []byte("key2"), x.Key(id2))
+	assert.Equal(t, 2, x.Len())
+
+	x.Delete(id1)
+	_, exists = x.Lookup([]byte("key1"))
+	assert.False(t, exists)
+	_, exists = x.Lookup([]byte("key2"))
+	assert.True(t, exists)
+	assert.Equal(t, 1, x.Len())
+
+	// IDs are not reused.
+	id3, _ := x.Add([]byte("key1"))
+	assert.NotEqual(t, id1, id3)
+
+	x.Delete(id2)
+	x.Delete(id3)
+	assert.Empty(t, x.buckets)
+	assert.Equal(t, 0, x.Len())
+}
+
+func TestIndex_Clear(t *testing.T) {
+	x := New(collidingHash, bytes.Equal)
+	id1, _ := x.Add([]byte("key1"))
+	x.Clear()
+	assert.Equal(t, 0, x.Len())
+	_, exists := x.Lookup([]byte("key1"))
+	assert.False(t, exists)
+
+	id2, _ := x.Add([]byte("key1"))
+	assert.NotEqual(t, id1, id2)
+}
@@ -0,0 +1,179 @@
+// Package keyorder keeps keys in an order in which they can be added, removed
+// and moved to the front or back in amortized O(1).
+//
+// Every key has a sequence number which increases from the front to the back,
+// so an iteration can be resumed after a position even if the key at that position was removed.
+package keyorder
+
+import (
+	"iter"
+	"sort"
+)
+
+// firstBackSeq is the sequence number of the first key added at the back.
+// Keys moved to the front get decreasing sequence numbers below it.
+const firstBackSeq = 1 << 63
+
+// List is an ordered list of unique keys. It is not safe for concurrent use.
+type List[K comparable] struct {
+	seqs map[K]uint64
+	// front contains the keys moved to the front, the frontmost key last, back all other keys.
+	// Removed and moved keys stay in the slices until stale entries make up half of them.
+	front     []entry[K]
+	back      []entry[K]
+	stale     int
+	nextFront uint64
+	nextBack  uint64
+}
+
+type entry[K comparable] struct {
+	key K
+	seq uint64
+}
+
+// New creates a new empty List.
+func New[K comparable]() *List[K] {
+	return &List[K]{
+		seqs:      make(map[K]uint64),
+		nextFront: firstBackSeq - 1,
+		nextBack:  firstBackSeq,
+	}
+}
+
+// PushBack adds a key at the back. It returns false and keeps the position if the key already exists.
+func (l *List[K]) PushBack(key K) bool {
+	if _, exists := l.seqs[key]; exists {
+		return false
+	}
+	l.pushBack(key)
+	return true
+}
+
+func (l *List[K]) pushBack(key K) {
+	l.seqs[key] = l.nextBack
+	l.back = append(l.back, entry[K]{key: key, seq: l.nextBack})
+	l.nextBack++
+}
+
+// Remove removes a key. It returns false if the key does not exist.
+func (l *List[K]) Remove(key K) bool {
+	if _, exists := l.seqs[key]; !exists {
+		return false
+	}
+	delete(l.seqs, key)
+	l.markStale()
+	return true
+}
+
+// MoveToFront moves a key to the front. It returns false if the key does not exist.
+func (l *List[K]) MoveToFront(key K) bool {
+	if _, exists := l.seqs[key]; !exists {
+		return false
+	}
+	l.seqs[key] = l.nextFront
+	l.front = append(l.front, entry[K]{key: key, seq: l.nextFront})
+	l.nextFront--
+	l.markStale()
+	return true
+}
+
+// MoveToBack moves a key to the back. It returns false if the key does not exist.
+func (l *List[K]) MoveToBack(key K) bool {
+	if _, exists := l.seqs[key]; !exists {
+		return false
+	}
+	l.pushBack(key)
+	l.markStale()
+	return true
+}
+
+// Rekey replaces oldKey with newKey at the same position. newKey must not exist.
+func (l *List[K]) Rekey(oldKey K, newKey K) {
+	seq, exists := l.seqs[oldKey]
+	if !exists {
+		return
+	}
+	delete(l.seqs, oldKey)
+	l.seqs[newKey] = seq
+	if seq >= firstBackSeq {
+		i := sort.Search(len(l.back), func(i int) bool { return l.back[i].seq >= seq })
+		l.back[i].key = newKey
+	} else {
+		i := sort.Search(len(l.front), func(i int) bool { return l.front[i].seq <= seq })
+		l.front[i].key = newKey
+	}
+}
+
+// markStale counts an entry which no longer matches its key and compacts the slices if half of them are stale.
+// The compacted entries are copied to new slices, so running iterations are not affected.
+func (l *List[K]) markStale() {
+	l.stale++
+	if l.stale <= len(l.seqs) {
+		return
+	}
+	l.front = l.live(l.front)
+	l.back = l.live(l.back)
+	l.stale = 0
+}
+
+func (l *List[K]) live(entries []entry[K]) []entry[K] {
+	var result []entry[K]
+	for _, e := range entries {
+		if l.isLive(e) {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+func (l *List[K]) isLive(e entry[K]) bool {
+	seq, exists := l.seqs[e.key]
+	return exists && seq == e.seq
+}
+
+// Len returns the number of keys.
+func (l *List[K]) Len() int {
+	return len(l.seqs)
+}
+
+// All returns an iterator over all keys from the front to the back.
+// Keys may be removed or moved during the iteration, keys added during the iteration may be skipped.
+func (l *List[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range l.After(0) {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// After returns an iterator over the keys with a sequence number greater than seq
+// together with their sequence numbers, from the front to the back.
+// Sequence numbers are never reused, not even after Clear, and are never 0.
+func (l *List[K]) After(seq uint64) iter.Seq2[K, uint64] {
+	return func(yield func(K, uint64) bool) {
+		front, back := l.front, l.back
+		// front is in descending order of the sequence numbers.
+		end := sort.Search(len(front), func(i int) bool { return front[i].seq <= seq })
+		for i := end - 1; i >= 0; i-- {
+			if l.isLive(front[i]) && !yield(front[i].key, front[i].seq) {
+				return
+			}
+		}
+		start := sort.Search(len(back), func(i int) bool { return back[i].seq > seq })
+		for _, e := range back[start:] {
+			if l.isLive(e) && !yield(e.key, e.seq) {
+				return
+			}
+		}
+	}
+}
+
+// Clear removes all keys. The sequence numbers continue, so old positions do not match new keys.
+func (l *List[K]) Clear() {
+	l.seqs = make(map[K]uint64)
+	l.front = nil
+	l.back = nil
+	l.stale = 0
+}
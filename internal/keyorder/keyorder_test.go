@@ -0,0 +1,118 @@
+package keyorder
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList(t *testing.T) {
+	l := New[string]()
+	assert.True(t, l.PushBack("a"))
+	assert.True(t, l.PushBack("b"))
+	assert.True(t, l.PushBack("c"))
+	assert.False(t, l.PushBack("a"))
+	assert.Equal(t, []string{"a", "b", "c"}, slices.Collect(l.All()))
+
+	assert.True(t, l.MoveToFront("c"))
+	assert.Equal(t, []string{"c", "a", "b"}, slices.Collect(l.All()))
+	assert.True(t, l.MoveToBack("c"))
+	assert.True(t, l.MoveToBack("c"))
+	assert.Equal(t, []string{"a", "b", "c"}, slices.Collect(l.All()))
+	assert.True(t, l.MoveToFront("a"))
+	assert.False(t, l.MoveToFront("x"))
+	assert.False(t, l.MoveToBack("x"))
+
+	l.Rekey("b", "x")
+	assert.Equal(t, []string{"a", "x", "c"}, slices.Collect(l.All()))
+
+	assert.True(t, l.Remove("a"))
+	assert.False(t, l.Remove("a"))
+	assert.True(t, l.Remove("c"))
+	assert.Equal(t, []string{"x"}, slices.Collect(l.All()))
+	assert.True(t, l.MoveToFront("x"))
+	assert.True(t, l.MoveToBack("x"))
+	assert.Equal(t, 1, l.Len())
+
+	l.Clear()
+	assert.Equal(t, 0, l.Len())
+	assert.Empty(t, slices.Collect(l.All()))
+	assert.True(t, l.PushBack("a"))
+	assert.Equal(t, []string{"a"}, slices.Collect(l.All()))
+}
+
+func TestList_RemoveWhileIterating(t *testing.T) {
+	l := New[int]()
+	for i := range 5 {
+		l.PushBack(i)
+	}
+	for key := range l.All() {
+		if key%2 == 0 {
+			l.Remove(key)
+		}
+	}
+	assert.Equal(t, []int{1, 3}, slices.Collect(l.All()))
+}
+
+func TestList_After(t *testing.T) {
+	l := New[int]()
+	var seqs []uint64
+	for i := range 6 {
+		l.PushBack(i)
+	}
+	for _, seq := range l.After(0) {
+		seqs = append(seqs, seq)
+	}
+	assert.True(t, slices.IsSorted(seqs))
+
+	// Resuming after a removed key continues with the keys behind it.
+	l.Remove(2)
+	assert.Equal(t, []int{3, 4, 5}, slices.Collect(keys(l.After(seqs[2]))))
+
+	// A key moved to the front is before every position, a key moved to the back after it.
+	l.MoveToFront(5)
+	l.MoveToBack(0)
+	assert.Equal(t, []int{5, 1, 3, 4, 0}, slices.Collect(l.All()))
+	assert.Equal(t, []int{3, 4, 0}, slices.Collect(keys(l.After(seqs[2]))))
+
+	// The positions stay valid after the slices are compacted.
+	for i := 10; i < 20; i++ {
+		l.PushBack(i)
+		l.Remove(i)
+	}
+	assert.Equal(t, []int{3, 4, 0}, slices.Collect(keys(l.After(seqs[2]))))
+
+	l.Rekey(5, 50)
+	l.Rekey(3, 30)
+	assert.Equal(t, []int{50, 1, 30, 4, 0}, slices.Collect(l.All()))
+
+	l.Clear()
+	l.PushBack(7)
+	assert.Equal(t, []int{7}, slices.Collect(keys(l.After(seqs[5]))))
+}
+
+func TestList_MoveWhileIterating(t *testing.T) {
+	l := New[int]()
+	for i := range 5 {
+		l.PushBack(i)
+	}
+	var visited []int
+	for key := range l.All() {
+		visited = append(visited, key)
+		l.MoveToBack(key)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, visited)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, slices.Collect(l.All()))
+}
+
+func keys[K any](seq iter.Seq2[K, uint64]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for key := range seq {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Package slab stores values in fixed-size chunks which are addressed by integer handles.
+// Compared to a map, it needs no hashing to access a value and allocates one chunk for many values.
+package slab
+
+// chunkSize is the number of values per chunk.
+const chunkSize = 1024
+
+// Handle addresses a value of a Slab. The handle of a freed value is reused by the next Alloc.
+type Handle uint32
+
+// Slab stores values of type T. Its zero value is an empty slab. It is not safe for concurrent use.
+type Slab[T any] struct {
+	chunks []*[chunkSize]T
+	free   []Handle
+	next   Handle // first handle which was never allocated
+}
+
+// Alloc stores a value and returns its handle.
+func (s *Slab[T]) Alloc(value T) Handle {
+	var h Handle
+	if n := len(s.free); n > 0 {
+		h = s.free[n-1]
+		s.free = s.free[:n-1]
+	} else {
+		h = s.next
+		s.next++
+		if int(h/chunkSize) == len(s.chunks) {
+			s.chunks = append(s.chunks, new([chunkSize]T))
+		}
+	}
+	*s.Get(h) = value
+	return h
+}
+
+// Get returns a pointer to the value of a handle. It stays valid until the handle is freed.
+func (s *Slab[T]) Get(h Handle) *T {
+	return &s.chunks[h/chunkSize][h%chunkSize]
+}
+
+// Free releases a handle. Its value is zeroed, so it does not keep any memory alive.
+func (s *Slab[T]) Free(h Handle) {
+	var zero T
+	*s.Get(h) = zero
+	s.free = append(s.free, h)
+}
+
+// Len returns the number of allocated handles.
+func (s *Slab[T]) Len() int {
+	return int(s.next) - len(s.free)
+}
+
+// Clear frees all handles and releases the chunks.
+func (s *Slab[T]) Clear() {
+	s.chunks = nil
+	s.free = nil
+	s.next = 0
+}
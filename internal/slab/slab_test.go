@@ -0,0 +1,57 @@
+package slab
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlab(t *testing.T) {
+	var s Slab[string]
+	a := s.Alloc("a")
+	b := s.Alloc("b")
+	assert.NotEqual(t, a, b)
+	assert.Equal(t, "a", *s.Get(a))
+	assert.Equal(t, "b", *s.Get(b))
+	assert.Equal(t, 2, s.Len())
+
+	*s.Get(a) = "x"
+	assert.Equal(t, "x", *s.Get(a))
+
+	s.Free(a)
+	assert.Equal(t, 1, s.Len())
+	assert.Equal(t, a, s.Alloc("c"))
+	assert.Equal(t, "c", *s.Get(a))
+
+	s.Clear()
+	assert.Equal(t, 0, s.Len())
+	assert.Equal(t, Handle(0), s.Alloc("d"))
+}
+
+func TestSlab_Chunks(t *testing.T) {
+	var s Slab[int]
+	handles := make([]Handle, 3*chunkSize)
+	for n := range handles {
+		handles[n] = s.Alloc(n)
+	}
+	assert.Len(t, s.chunks, 3)
+	// Pointers stay valid when new chunks are added.
+	first := s.Get(handles[0])
+	s.Alloc(-1)
+	assert.Same(t, first, s.Get(handles[0]))
+	for n, h := range handles {
+		assert.Equal(t, n, *s.Get(h))
+	}
+
+	for _, h := range handles[:chunkSize] {
+		s.Free(h)
+	}
+	assert.Equal(t, 0, *s.Get(handles[0]))
+	assert.Equal(t, 2*chunkSize+1, s.Len())
+	// Freed handles are reused before new chunks are added.
+	for n := range chunkSize {
+		s.Alloc(n)
+	}
+	assert.Len(t, s.chunks, 4)
+	assert.Equal(t, 3*chunkSize+1, s.Len())
+}
@@ -0,0 +1,218 @@
+// Package sortedset keeps ordered keys in a treap,
+// so adding, deleting and finding a key takes O(log n) expected time.
+package sortedset
+
+import (
+	"cmp"
+	"iter"
+	"math/rand/v2"
+)
+
+// Set is a set of ordered keys. It is not safe for concurrent use.
+// The zero value is an empty set.
+type Set[K cmp.Ordered] struct {
+	root *node[K]
+	len  int
+}
+
+// node is a node of the treap. The keys are a binary search tree,
+// the priorities are a max-heap, which keeps the tree balanced with a high probability.
+type node[K cmp.Ordered] struct {
+	key         K
+	priority    uint64
+	left, right *node[K]
+}
+
+// Add adds a key to the set. It returns false if the key already exists.
+func (s *Set[K]) Add(key K) bool {
+	var added bool
+	s.root, added = insert(s.root, key)
+	if added {
+		s.len++
+	}
+	return added
+}
+
+func insert[K cmp.Ordered](n *node[K], key K) (*node[K], bool) {
+	if n == nil {
+		return &node[K]{key: key, priority: rand.Uint64()}, true
+	}
+	var added bool
+	switch c := cmp.Compare(key, n.key); {
+	case c < 0:
+		n.left, added = insert(n.left, key)
+		if n.left.priority > n.priority {
+			n = rotateRight(n)
+		}
+	case c > 0:
+		n.right, added = insert(n.right, key)
+		if n.right.priority > n.priority {
+			n = rotateLeft(n)
+		}
+	}
+	return n, added
+}
+
+func rotateRight[K cmp.Ordered](n *node[K]) *node[K] {
+	left := n.left
+	n.left, left.right = left.right, n
+	return left
+}
+
+func rotateLeft[K cmp.Ordered](n *node[K]) *node[K] {
+	right := n.right
+	n.right, right.left = right.left, n
+	return right
+}
+
+// Delete removes a key from the set. It returns false if the key does not exist.
+func (s *Set[K]) Delete(key K) bool {
+	var deleted bool
+	s.root, deleted = remove(s.root, key)
+	if deleted {
+		s.len--
+	}
+	return deleted
+}
+
+func remove[K cmp.Ordered](n *node[K], key K) (*node[K], bool) {
+	if n == nil {
+		return nil, false
+	}
+	var deleted bool
+	switch c := cmp.Compare(key, n.key); {
+	case c < 0:
+		n.left, deleted = remove(n.left, key)
+	case c > 0:
+		n.right, deleted = remove(n.right, key)
+	default:
+		return merge(n.left, n.right), true
+	}
+	return n, deleted
+}
+
+// merge joins two treaps, all keys of left must be less than the keys of right.
+func merge[K cmp.Ordered](left *node[K], right *node[K]) *node[K] {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case left.priority > right.priority:
+		left.right = merge(left.right, right)
+		return left
+	default:
+		right.left = merge(left, right.left)
+		return right
+	}
+}
+
+// Len returns the number of keys in the set.
+func (s *Set[K]) Len() int {
+	return s.len
+}
+
+// Min returns the smallest key. It returns false if the set is empty.
+func (s *Set[K]) Min() (K, bool) {
+	n := s.root
+	if n == nil {
+		var zero K
+		return zero, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.key, true
+}
+
+// Max returns the largest key. It returns false if the set is empty.
+func (s *Set[K]) Max() (K, bool) {
+	n := s.root
+	if n == nil {
+		var zero K
+		return zero, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.key, true
+}
+
+// Ascend returns an iterator over all keys in ascending order.
+// The set must not be modified during the iteration.
+func (s *Set[K]) Ascend() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		s.ascend(yield, nil, nil)
+	}
+}
+
+// AscendRange returns an iterator over the keys in the range [greaterOrEqual, lessThan) in ascending order.
+// The set must not be modified during the iteration.
+func (s *Set[K]) AscendRange(greaterOrEqual K, lessThan K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		s.ascend(yield, &greaterOrEqual, &lessThan)
+	}
+}
+
+// AscendGreater returns an iterator over the keys greater than key in ascending order.
+// The set must not be modified during the iteration.
+func (s *Set[K]) AscendGreater(key K) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		s.ascend(func(k K) bool {
+			return k == key || yield(k)
+		}, &key, nil)
+	}
+}
+
+// ascend yields the keys from lower (inclusive) to upper (exclusive). A nil bound is unbounded.
+func (s *Set[K]) ascend(yield func(K) bool, lower *K, upper *K) {
+	var stack []*node[K]
+	for n := s.root; n != nil; {
+		if lower != nil && n.key < *lower {
+			n = n.right
+		} else {
+			stack = append(stack, n)
+			n = n.left
+		}
+	}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if upper != nil && n.key >= *upper {
+			return
+		}
+		if !yield(n.key) {
+			return
+		}
+		for child := n.right; child != nil; child = child.left {
+			stack = append(stack, child)
+		}
+	}
+}
+
+// Descend returns an iterator over all keys in descending order.
+// The set must not be modified during the iteration.
+func (s *Set[K]) Descend() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		var stack []*node[K]
+		for n := s.root; n != nil; n = n.right {
+			stack = append(stack, n)
+		}
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !yield(n.key) {
+				return
+			}
+			for child := n.left; child != nil; child = child.right {
+				stack = append(stack, child)
+			}
+		}
+	}
+}
+
+// Clear removes all keys.
+func (s *Set[K]) Clear() {
+	s.root = nil
+	s.len = 0
+}
@@ -0,0 +1,94 @@
+package sortedset
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	var s Set[int]
+	_, exists := s.Min()
+	assert.False(t, exists)
+	_, exists = s.Max()
+	assert.False(t, exists)
+
+	assert.True(t, s.Add(3))
+	assert.True(t, s.Add(1))
+	assert.True(t, s.Add(2))
+	assert.False(t, s.Add(2))
+	assert.Equal(t, 3, s.Len())
+	assert.Equal(t, []int{1, 2, 3}, slices.Collect(s.Ascend()))
+	assert.Equal(t, []int{3, 2, 1}, slices.Collect(s.Descend()))
+	minKey, _ := s.Min()
+	maxKey, _ := s.Max()
+	assert.Equal(t, 1, minKey)
+	assert.Equal(t, 3, maxKey)
+
+	assert.True(t, s.Delete(2))
+	assert.False(t, s.Delete(2))
+	assert.Equal(t, []int{1, 3}, slices.Collect(s.Ascend()))
+
+	s.Clear()
+	assert.Equal(t, 0, s.Len())
+	assert.Empty(t, slices.Collect(s.Ascend()))
+}
+
+func TestSet_AscendRange(t *testing.T) {
+	var s Set[int]
+	for key := range 10 {
+		s.Add(key * 2)
+	}
+	assert.Equal(t, []int{4, 6, 8}, slices.Collect(s.AscendRange(3, 10)))
+	assert.Equal(t, []int{4, 6, 8, 10}, slices.Collect(s.AscendRange(4, 11)))
+	assert.Empty(t, slices.Collect(s.AscendRange(5, 5)))
+	assert.Empty(t, slices.Collect(s.AscendRange(20, 30)))
+
+	var keys []int
+	for key := range s.AscendRange(0, 100) {
+		keys = append(keys, key)
+		if len(keys) == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 2}, keys)
+}
+
+func TestSet_AscendGreater(t *testing.T) {
+	var s Set[int]
+	for key := range 5 {
+		s.Add(key * 2)
+	}
+	assert.Equal(t, []int{6, 8}, slices.Collect(s.AscendGreater(4)))
+	assert.Equal(t, []int{6, 8}, slices.Collect(s.AscendGreater(5)))
+	assert.Equal(t, []int{0, 2, 4, 6, 8}, slices.Collect(s.AscendGreater(-1)))
+	assert.Empty(t, slices.Collect(s.AscendGreater(8)))
+}
+
+func TestSet_Random(t *testing.T) {
+	rng := rand.New(rand.NewPCG(1, 2))
+	var s Set[int]
+	expected := make(map[int]bool)
+	for range 10_000 {
+		key := rng.IntN(1000)
+		if rng.IntN(3) == 0 {
+			assert.Equal(t, expected[key], s.Delete(key))
+			delete(expected, key)
+		} else {
+			assert.Equal(t, !expected[key], s.Add(key))
+			expected[key] = true
+		}
+	}
+
+	keys := make([]int, 0, len(expected))
+	for key := range expected {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	assert.Equal(t, len(keys), s.Len())
+	assert.Equal(t, keys, slices.Collect(s.Ascend()))
+	slices.Reverse(keys)
+	assert.Equal(t, keys, slices.Collect(s.Descend()))
+}
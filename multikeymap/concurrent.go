@@ -1,26 +1,24 @@
 package multikeymap
 
 import (
-	"fmt"
+	"iter"
+	"slices"
 	"sync"
 )
 
 // ConcurrentMultiKeyMap is the same as MultiKeyMap, but it is safe for concurrent use.
 // It uses a RWMutex to protect the map from concurrent reads and writes.
 // Therefore, it is slower than MultiKeyMap, but it is safe for concurrent use.
+// The wrapped MultiKeyMap is not exposed, so it can only be accessed while holding the lock.
 type ConcurrentMultiKeyMap[K comparable, V any] struct {
-	mu sync.RWMutex
-	MultiKeyMap[K, V]
+	mu   sync.RWMutex
+	base MultiKeyMap[K, V]
 }
 
 // NewConcurrent creates a new ConcurrentMultiKeyMap instance.
-func NewConcurrent[K comparable, V any]() *ConcurrentMultiKeyMap[K, V] {
+func NewConcurrent[K comparable, V any](opts ...Option) *ConcurrentMultiKeyMap[K, V] {
 	return &ConcurrentMultiKeyMap[K, V]{
-		MultiKeyMap: MultiKeyMap[K, V]{
-			primary:     make(map[K]V),
-			secondary:   make(map[string]map[string]K),
-			secondaryTo: make(map[K]map[string]string),
-		},
+		base: *New[K, V](opts...),
 	}
 }
 
@@ -28,30 +26,28 @@ func NewConcurrent[K comparable, V any]() *ConcurrentMultiKeyMap[K, V] {
 func (m *ConcurrentMultiKeyMap[K, V]) Put(primaryKey K, value V) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.primary[primaryKey] = value
+	m.base.Put(primaryKey, value)
 }
 
 // PutSecondaryKeys adds secondary keys under a group for a primary key.
 func (m *ConcurrentMultiKeyMap[K, V]) PutSecondaryKeys(primaryKey K, group string, keys ...string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	if m.secondary[group] == nil {
-		m.secondary[group] = make(map[string]K)
-	}
-	if m.secondaryTo[primaryKey] == nil {
-		m.secondaryTo[primaryKey] = make(map[string]string)
-	}
-	for _, key := range keys {
-		m.secondary[group][key] = primaryKey
-		m.secondaryTo[primaryKey][group] = key
-	}
+	m.base.PutSecondaryKeys(primaryKey, group, keys...)
+}
+
+// RemoveSecondaryKeys removes secondary keys from a group if they point to the given primary key.
+func (m *ConcurrentMultiKeyMap[K, V]) RemoveSecondaryKeys(primaryKey K, group string, keys ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.base.RemoveSecondaryKeys(primaryKey, group, keys...)
 }
 
 // HasPrimaryKey checks if a primary key exists.
 func (m *ConcurrentMultiKeyMap[K, V]) HasPrimaryKey(primaryKey K) bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	_, exists := m.primary[primaryKey]
+	_, exists := m.base.primary[primaryKey]
 	return exists
 }
 
@@ -59,11 +55,7 @@ func (m *ConcurrentMultiKeyMap[K, V]) HasPrimaryKey(primaryKey K) bool {
 func (m *ConcurrentMultiKeyMap[K, V]) HasSecondaryKey(group string, key string) bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	if groupKeys, exists := m.secondary[group]; exists {
-		_, exists := groupKeys[key]
-		return exists
-	}
-	return false
+	return m.base.HasSecondaryKey(group, key)
 }
 
 // GetAllKeyGroups returns all key groups and their secondary keys.
@@ -72,7 +64,7 @@ func (m *ConcurrentMultiKeyMap[K, V]) GetAllKeyGroups() map[string]map[string]K
 	defer m.mu.RUnlock()
 	// Create a copy of the key groups to avoid concurrency issues
 	result := make(map[string]map[string]K)
-	for group, keys := range m.secondary {
+	for group, keys := range m.base.secondary {
 		result[group] = make(map[string]K)
 		for key, primary := range keys {
 			result[group][key] = primary
@@ -85,23 +77,23 @@ func (m *ConcurrentMultiKeyMap[K, V]) GetAllKeyGroups() map[string]map[string]K
 func (m *ConcurrentMultiKeyMap[K, V]) Remove(primaryKey K) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	delete(m.primary, primaryKey)
-	if groups, exists := m.secondaryTo[primaryKey]; exists {
-		for group, key := range groups {
-			delete(m.secondary[group], key)
-			if len(m.secondary[group]) == 0 {
-				delete(m.secondary, group)
-			}
-		}
-		delete(m.secondaryTo, primaryKey)
-	}
+	m.base.Remove(primaryKey)
+}
+
+// RekeyPrimary moves the value and all secondary keys of oldPrimaryKey to newPrimaryKey.
+// It returns ErrPrimaryKeyNotFound if oldPrimaryKey does not exist
+// and ErrPrimaryKeyExists if newPrimaryKey already exists.
+func (m *ConcurrentMultiKeyMap[K, V]) RekeyPrimary(oldPrimaryKey K, newPrimaryKey K) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.base.RekeyPrimary(oldPrimaryKey, newPrimaryKey)
 }
 
 // Get returns a value by primary key.
 func (m *ConcurrentMultiKeyMap[K, V]) Get(primaryKey K) (V, bool) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	value, exists := m.primary[primaryKey]
+	value, exists := m.base.primary[primaryKey]
 	return value, exists
 }
 
@@ -110,53 +102,178 @@ func (m *ConcurrentMultiKeyMap[K, V]) GetBySecondaryKey(group string, key string
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
-	if groupKeys, exists := m.secondary[group]; exists {
-		primaryKey, exists := groupKeys[key]
-		if exists {
-			value, exists := m.primary[primaryKey]
-			return value, exists
+	return m.base.GetBySecondaryKey(group, key)
+}
+
+// Groups returns the names of all groups which have at least one secondary key, in lexical order.
+func (m *ConcurrentMultiKeyMap[K, V]) Groups() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.Groups()
+}
+
+// GroupSize returns the number of secondary keys in a group.
+func (m *ConcurrentMultiKeyMap[K, V]) GroupSize(group string) int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.GroupSize(group)
+}
+
+// KeysInGroup returns an iterator over the secondary keys of a group in no particular order.
+// The keys are collected under a read lock when the iteration starts,
+// so the map may be modified during the iteration without affecting it.
+func (m *ConcurrentMultiKeyMap[K, V]) KeysInGroup(group string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		m.mu.RLock()
+		keys := slices.Collect(m.base.KeysInGroup(group))
+		m.mu.RUnlock()
+		for _, key := range keys {
+			if !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// SecondaryKeysOf returns the secondary keys of a primary key by group.
+// The keys of each group are in lexical order. Without secondary keys, the map is empty.
+func (m *ConcurrentMultiKeyMap[K, V]) SecondaryKeysOf(primaryKey K) map[string][]string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.SecondaryKeysOf(primaryKey)
+}
+
+// GroupBy returns the values of all entries with a secondary key in the group, keyed by that secondary key.
+// Secondary keys whose primary key has no value are left out.
+// A secondary key points to a single primary key, so each slice has exactly one element.
+func (m *ConcurrentMultiKeyMap[K, V]) GroupBy(group string) map[string][]V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.GroupBy(group)
+}
+
+// Query returns an iterator over all entries matching the query.
+// The matching entries are collected under a read lock when the iteration starts,
+// so the map may be modified during the iteration without affecting it.
+func (m *ConcurrentMultiKeyMap[K, V]) Query(q Query) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.mu.RLock()
+		entries := m.base.queryEntries(q)
+		m.mu.RUnlock()
+		for _, entry := range entries {
+			if !yield(entry.PrimaryKey, entry.Value) {
+				return
+			}
 		}
 	}
-	return *new(V), false
+}
+
+// Page returns up to limit entries following the cursor in insertion order, and the cursor for the next page.
+// Each page is read under a read lock. Between pages the map may be modified:
+// entries which exist during the whole pagination and are not moved are returned exactly once,
+// entries which are put after the pagination started are returned on a later page,
+// and removed entries are not returned anymore. An entry which is removed and put again
+// or moved to the back may be returned twice.
+func (m *ConcurrentMultiKeyMap[K, V]) Page(cursor Cursor, limit int) ([]Entry[K, V], Cursor, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.Page(cursor, limit)
+}
+
+// GroupPage returns up to limit secondary keys of a group following the cursor, in lexical order,
+// and the cursor for the next page.
+// Each page is read under a write lock, as the first page of a group sorts its keys.
+// Between pages the map may be modified:
+// secondary keys which exist during the whole pagination are returned exactly once,
+// secondary keys which are added after the pagination started are only returned
+// if they are sorted after the cursor, and removed keys are not returned anymore.
+func (m *ConcurrentMultiKeyMap[K, V]) GroupPage(group string, cursor Cursor, limit int) ([]GroupEntry[K, V], Cursor, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.base.GroupPage(group, cursor, limit)
 }
 
 // Size returns the number of primary keys in the map.
 func (m *ConcurrentMultiKeyMap[K, V]) Size() int {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return len(m.primary)
+	return len(m.base.primary)
+}
+
+// Keys returns a slice of all primary keys in the map.
+func (m *ConcurrentMultiKeyMap[K, V]) Keys() []K {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.Keys()
+}
+
+// MoveToFront moves an entry to the front of the insertion order.
+// It returns ErrNoInsertionOrder if the map was not created with WithInsertionOrder
+// and ErrPrimaryKeyNotFound if the primary key does not exist.
+func (m *ConcurrentMultiKeyMap[K, V]) MoveToFront(primaryKey K) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.base.MoveToFront(primaryKey)
+}
+
+// MoveToBack moves an entry to the back of the insertion order.
+// It returns ErrNoInsertionOrder if the map was not created with WithInsertionOrder
+// and ErrPrimaryKeyNotFound if the primary key does not exist.
+func (m *ConcurrentMultiKeyMap[K, V]) MoveToBack(primaryKey K) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.base.MoveToBack(primaryKey)
+}
+
+// Has checks if a primary key exists. It is the same as HasPrimaryKey.
+func (m *ConcurrentMultiKeyMap[K, V]) Has(primaryKey K) bool {
+	return m.HasPrimaryKey(primaryKey)
+}
+
+// All returns an iterator over all primary keys and their values.
+// The entries are collected under a read lock when the iteration starts,
+// so the map may be modified while iterating.
+func (m *ConcurrentMultiKeyMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.mu.RLock()
+		entries := make([]Entry[K, V], 0, len(m.base.primary))
+		for primaryKey, value := range m.base.All() {
+			entries = append(entries, Entry[K, V]{PrimaryKey: primaryKey, Value: value})
+		}
+		m.mu.RUnlock()
+		for _, entry := range entries {
+			if !yield(entry.PrimaryKey, entry.Value) {
+				return
+			}
+		}
+	}
 }
 
 // Empty checks if the map is empty.
 func (m *ConcurrentMultiKeyMap[K, V]) Empty() bool {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return len(m.primary) == 0
+	return len(m.base.primary) == 0
 }
 
 // Values returns a slice of all values in the map.
 func (m *ConcurrentMultiKeyMap[K, V]) Values() []V {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	values := make([]V, 0, len(m.primary))
-	for _, value := range m.primary {
-		values = append(values, value)
-	}
-	return values
+	return m.base.Values()
 }
 
 // Clear removes all elements from the map.
 func (m *ConcurrentMultiKeyMap[K, V]) Clear() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.primary = make(map[K]V)
-	m.secondary = make(map[string]map[string]K)
-	m.secondaryTo = make(map[K]map[string]string)
+	m.base.Clear()
 }
 
 // String returns a string representation of the map.
+// With WithInsertionOrder, the entries are listed in insertion order.
 func (m *ConcurrentMultiKeyMap[K, V]) String() string {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
-	return fmt.Sprintf("ConcurrentMultiKeyMap: %v", m.primary)
+	return "Concurrent" + m.base.String()
 }
@@ -2,12 +2,14 @@ package multikeymap
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
 	"sync"
 	"testing"
 
 	"github.com/aeimer/go-multikeymap/container"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func ExampleNewConcurrent() {
@@ -32,6 +34,38 @@ func TestConcurrentMultiKeyMap_ImplementsContainerInterface(t *testing.T) {
 	}
 }
 
+func TestConcurrentMultiKeyMap_KeyedContainer(t *testing.T) {
+	mm := NewConcurrent[string, int]()
+	mm.Put("key1", 1)
+	mm.Put("key2", 2)
+
+	var keyed container.KeyedContainer[string, int] = mm
+	assert.ElementsMatch(t, []string{"key1", "key2"}, keyed.Keys())
+	assert.True(t, keyed.Has("key1"))
+	assert.False(t, keyed.Has("key3"))
+	value, exists := keyed.Get("key2")
+	assert.True(t, exists)
+	assert.Equal(t, 2, value)
+	assert.Equal(t, map[string]int{"key1": 1, "key2": 2}, container.Collect(keyed))
+}
+
+func TestConcurrentMultiKeyMap_HidesUnderlyingMap(t *testing.T) {
+	concurrentType := reflect.TypeFor[ConcurrentMultiKeyMap[string, int]]()
+	for i := range concurrentType.NumField() {
+		field := concurrentType.Field(i)
+		assert.False(t, field.IsExported(), "field %s allows access without locking", field.Name)
+	}
+
+	// Every method of MultiKeyMap must be implemented with locking, as nothing is promoted.
+	pointerType := reflect.PointerTo(concurrentType)
+	baseType := reflect.TypeFor[*MultiKeyMap[string, int]]()
+	for i := range baseType.NumMethod() {
+		method := baseType.Method(i)
+		_, exists := pointerType.MethodByName(method.Name)
+		assert.True(t, exists, "method %s is missing", method.Name)
+	}
+}
+
 func TestConcurrentMultiKeyMap_SetAndGet(t *testing.T) {
 	mm := NewConcurrent[string, int]()
 	mm.Put("key1", 1)
@@ -81,6 +115,52 @@ func TestConcurrentMultiKeyMap_Remove(t *testing.T) {
 	}
 }
 
+func TestConcurrentMultiKeyMap_RekeyPrimary(t *testing.T) {
+	mm := NewConcurrent[string, int]()
+	mm.Put("key1", 1)
+	mm.PutSecondaryKeys("key1", "group1", "secKey1")
+
+	require.NoError(t, mm.RekeyPrimary("key1", "key2"))
+	require.ErrorIs(t, mm.RekeyPrimary("key1", "key3"), ErrPrimaryKeyNotFound)
+	value, exists := mm.GetBySecondaryKey("group1", "secKey1")
+	assert.True(t, exists)
+	assert.Equal(t, 1, value)
+	assert.False(t, mm.HasPrimaryKey("key1"))
+}
+
+func TestConcurrentMultiKeyMap_WithInsertionOrder(t *testing.T) {
+	mm := NewConcurrent[string, int](WithInsertionOrder())
+	mm.Put("key2", 2)
+	mm.Put("key1", 1)
+	mm.Put("key3", 3)
+	require.NoError(t, mm.MoveToFront("key3"))
+	require.NoError(t, mm.MoveToBack("key2"))
+	assert.Equal(t, []string{"key3", "key1", "key2"}, mm.Keys())
+	assert.Equal(t, []int{3, 1, 2}, mm.Values())
+	require.ErrorIs(t, mm.MoveToFront("key4"), ErrPrimaryKeyNotFound)
+	require.ErrorIs(t, NewConcurrent[string, int]().MoveToBack("key1"), ErrNoInsertionOrder)
+}
+
+func TestConcurrentMultiKeyMap_Groups(t *testing.T) {
+	mm := NewConcurrent[string, int]()
+	mm.Put("key1", 1)
+	mm.PutSecondaryKeys("key1", "group1", "secKey2", "secKey1")
+	mm.PutSecondaryKeys("key1", "group2", "secKey3")
+	assert.Equal(t, []string{"group1", "group2"}, mm.Groups())
+	assert.Equal(t, 2, mm.GroupSize("group1"))
+	assert.Equal(t, map[string][]string{"group1": {"secKey1", "secKey2"}, "group2": {"secKey3"}}, mm.SecondaryKeysOf("key1"))
+	assert.Equal(t, map[string][]int{"secKey3": {1}}, mm.GroupBy("group2"))
+
+	// The keys are a snapshot, so the map can be modified while iterating.
+	var keys []string
+	for key := range mm.KeysInGroup("group1") {
+		mm.PutSecondaryKeys("key1", "group1", key+"x")
+		keys = append(keys, key)
+	}
+	assert.ElementsMatch(t, []string{"secKey1", "secKey2"}, keys)
+	assert.Equal(t, 4, mm.GroupSize("group1"))
+}
+
 func TestConcurrentMultiKeyMap_GetAllKeyGroups(t *testing.T) {
 	mm := NewConcurrent[string, int]()
 	mm.Put("key1", 1)
@@ -111,6 +191,14 @@ func TestConcurrentMultiKeyMap_String(t *testing.T) {
 	}
 }
 
+func TestConcurrentMultiKeyMap_String_InsertionOrder(t *testing.T) {
+	mm := NewConcurrent[string, int](WithInsertionOrder())
+	mm.Put("key2", 2)
+	mm.Put("key1", 1)
+	mm.Put("key3", 3)
+	assert.Equal(t, "ConcurrentMultiKeyMap: [{key2 2} {key1 1} {key3 3}]", mm.String())
+}
+
 func TestConcurrentMultiKeyMap_Size(t *testing.T) {
 	mm := NewConcurrent[string, int]()
 	mm.Put("key1", 1)
@@ -216,6 +304,47 @@ func concurrentRemove(t *testing.T, i int, wg *sync.WaitGroup, mm *ConcurrentMul
 	}
 }
 
+func TestConcurrentMultiKeyMap_Query(t *testing.T) {
+	mm := NewConcurrent[string, int]()
+	mm.Put("key1", 1)
+	mm.Put("key2", 2)
+	mm.PutSecondaryKeys("key1", "group1", "secKey1")
+	mm.PutSecondaryKeys("key2", "group1", "secKey2")
+
+	// Modifying the map during the iteration must not deadlock.
+	count := 0
+	for primaryKey := range mm.Query(In("group1", "secKey1", "secKey2")) {
+		mm.Remove(primaryKey)
+		count++
+	}
+	assert.Equal(t, 2, count)
+	assert.True(t, mm.Empty())
+}
+
+func TestConcurrentMultiKeyMap_Page(t *testing.T) {
+	mm := NewConcurrent[int, int]()
+	for n := range 5 {
+		mm.Put(n, n)
+		mm.PutSecondaryKeys(n, "group1", strconv.Itoa(n))
+	}
+
+	entries, cursor, err := mm.Page("", 3)
+	require.NoError(t, err)
+	assert.Len(t, entries, 3)
+	entries, cursor, err = mm.Page(cursor, 3)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, Cursor(""), cursor)
+
+	groupEntries, cursor, err := mm.GroupPage("group1", "", 3)
+	require.NoError(t, err)
+	assert.Len(t, groupEntries, 3)
+	groupEntries, cursor, err = mm.GroupPage("group1", cursor, 3)
+	require.NoError(t, err)
+	assert.Len(t, groupEntries, 2)
+	assert.Equal(t, Cursor(""), cursor)
+}
+
 // Benchmarks
 
 var benchmarkConcurrentSizes = []struct {
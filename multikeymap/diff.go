@@ -0,0 +1,126 @@
+package multikeymap
+
+// Patch describes the differences between two MultiKeyMaps.
+// It is created by Diff and can be applied to another map with Apply.
+type Patch[K comparable, V any] struct {
+	// Added contains the primary keys which only exist in the target map.
+	Added map[K]V
+	// Removed contains the primary keys which only exist in the source map, with their old values.
+	Removed map[K]V
+	// Changed contains the primary keys which exist in both maps but hold different values, with their new values.
+	Changed map[K]V
+	// AddedSecondaryKeys contains the secondary keys which only exist in the target map.
+	// Group -> SecondaryKey -> PrimaryKey
+	AddedSecondaryKeys map[string]map[string]K
+	// RemovedSecondaryKeys contains the secondary keys which only exist in the source map.
+	// A secondary key which points to another primary key in the target map is removed and added again.
+	// Group -> SecondaryKey -> PrimaryKey it pointed to in the source map
+	RemovedSecondaryKeys map[string]map[string]K
+}
+
+// Empty checks if the patch contains no changes.
+func (p *Patch[K, V]) Empty() bool {
+	return len(p.Added) == 0 &&
+		len(p.Removed) == 0 &&
+		len(p.Changed) == 0 &&
+		len(p.AddedSecondaryKeys) == 0 &&
+		len(p.RemovedSecondaryKeys) == 0
+}
+
+// Apply applies the patch to the given map.
+// Removals are applied before additions, so a patch created by Diff(a, b) turns a into b.
+// A removed secondary key is only removed if it still points to the primary key recorded in the patch.
+func (p *Patch[K, V]) Apply(m *MultiKeyMap[K, V]) {
+	for primaryKey := range p.Removed {
+		m.Remove(primaryKey)
+	}
+	for group, keys := range p.RemovedSecondaryKeys {
+		for key, primaryKey := range keys {
+			m.RemoveSecondaryKeys(primaryKey, group, key)
+		}
+	}
+	for primaryKey, value := range p.Added {
+		m.Put(primaryKey, value)
+	}
+	for primaryKey, value := range p.Changed {
+		m.Put(primaryKey, value)
+	}
+	for group, keys := range p.AddedSecondaryKeys {
+		for key, primaryKey := range keys {
+			m.PutSecondaryKeys(primaryKey, group, key)
+		}
+	}
+}
+
+// Diff returns the changes needed to turn map a into map b.
+// The function eq is used to decide if two values are equal.
+func Diff[K comparable, V any](a, b *MultiKeyMap[K, V], eq func(V, V) bool) *Patch[K, V] {
+	patch := &Patch[K, V]{
+		Added:                make(map[K]V),
+		Removed:              make(map[K]V),
+		Changed:              make(map[K]V),
+		AddedSecondaryKeys:   make(map[string]map[string]K),
+		RemovedSecondaryKeys: make(map[string]map[string]K),
+	}
+
+	for primaryKey, valueA := range a.primary {
+		valueB, exists := b.primary[primaryKey]
+		switch {
+		case !exists:
+			patch.Removed[primaryKey] = valueA
+		case !eq(valueA, valueB):
+			patch.Changed[primaryKey] = valueB
+		}
+	}
+	for primaryKey, valueB := range b.primary {
+		if _, exists := a.primary[primaryKey]; !exists {
+			patch.Added[primaryKey] = valueB
+		}
+	}
+
+	diffSecondaryKeys(patch.RemovedSecondaryKeys, a.secondary, b.secondary)
+	diffSecondaryKeys(patch.AddedSecondaryKeys, b.secondary, a.secondary)
+
+	return patch
+}
+
+// diffSecondaryKeys stores all secondary keys of from which are missing in or point elsewhere in other.
+func diffSecondaryKeys[K comparable](result, from, other map[string]map[string]K) {
+	for group, keys := range from {
+		for key, primaryKey := range keys {
+			if otherPrimaryKey, exists := other[group][key]; exists && otherPrimaryKey == primaryKey {
+				continue
+			}
+			if result[group] == nil {
+				result[group] = make(map[string]K)
+			}
+			result[group][key] = primaryKey
+		}
+	}
+}
+
+// Equal checks if both maps contain the same primary keys, values and secondary keys.
+// The function eq is used to decide if two values are equal.
+func Equal[K comparable, V any](a, b *MultiKeyMap[K, V], eq func(V, V) bool) bool {
+	if len(a.primary) != len(b.primary) || len(a.secondary) != len(b.secondary) {
+		return false
+	}
+	for primaryKey, valueA := range a.primary {
+		valueB, exists := b.primary[primaryKey]
+		if !exists || !eq(valueA, valueB) {
+			return false
+		}
+	}
+	for group, keysA := range a.secondary {
+		keysB := b.secondary[group]
+		if len(keysA) != len(keysB) {
+			return false
+		}
+		for key, primaryKeyA := range keysA {
+			if primaryKeyB, exists := keysB[key]; !exists || primaryKeyA != primaryKeyB {
+				return false
+			}
+		}
+	}
+	return true
+}
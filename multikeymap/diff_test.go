@@ -0,0 +1,112 @@
+package multikeymap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intEqual(a, b int) bool {
+	return a == b
+}
+
+func TestDiff(t *testing.T) {
+	a := New[string, int]()
+	a.Put("key1", 1)
+	a.Put("key2", 2)
+	a.Put("key3", 3)
+	a.PutSecondaryKeys("key1", "group1", "secKey1", "secKey2")
+	a.PutSecondaryKeys("key2", "group1", "secKey3")
+
+	b := New[string, int]()
+	b.Put("key1", 1)
+	b.Put("key2", 20)
+	b.Put("key4", 4)
+	b.PutSecondaryKeys("key1", "group1", "secKey1")
+	b.PutSecondaryKeys("key1", "group1", "secKey3")
+	b.PutSecondaryKeys("key4", "group2", "secKey4")
+
+	patch := Diff(a, b, intEqual)
+	assert.Equal(t, map[string]int{"key4": 4}, patch.Added)
+	assert.Equal(t, map[string]int{"key3": 3}, patch.Removed)
+	assert.Equal(t, map[string]int{"key2": 20}, patch.Changed)
+	assert.Equal(t, map[string]map[string]string{
+		"group1": {"secKey3": "key1"},
+		"group2": {"secKey4": "key4"},
+	}, patch.AddedSecondaryKeys)
+	assert.Equal(t, map[string]map[string]string{
+		"group1": {"secKey2": "key1", "secKey3": "key2"},
+	}, patch.RemovedSecondaryKeys)
+}
+
+func TestDiff_EqualMaps(t *testing.T) {
+	a := New[string, int]()
+	a.Put("key1", 1)
+	a.PutSecondaryKeys("key1", "group1", "secKey1")
+	b := New[string, int]()
+	b.Put("key1", 1)
+	b.PutSecondaryKeys("key1", "group1", "secKey1")
+
+	assert.True(t, Diff(a, b, intEqual).Empty())
+}
+
+func TestPatch_Apply(t *testing.T) {
+	a := New[string, int]()
+	a.Put("key1", 1)
+	a.Put("key2", 2)
+	a.Put("key3", 3)
+	a.PutSecondaryKeys("key1", "group1", "secKey1", "secKey2")
+	a.PutSecondaryKeys("key3", "group2", "secKey3")
+
+	b := New[string, int]()
+	b.Put("key1", 10)
+	b.Put("key2", 2)
+	b.Put("key4", 4)
+	b.PutSecondaryKeys("key1", "group1", "secKey1")
+	b.PutSecondaryKeys("key2", "group1", "secKey2")
+	b.PutSecondaryKeys("key4", "group2", "secKey3")
+
+	Diff(a, b, intEqual).Apply(a)
+	assert.True(t, Equal(a, b, intEqual))
+	assert.True(t, Diff(a, b, intEqual).Empty())
+}
+
+func TestPatch_Apply_KeepsSecondaryKeyOfOtherPrimaryKey(t *testing.T) {
+	a := New[string, int]()
+	a.Put("key1", 1)
+	a.PutSecondaryKeys("key1", "group1", "secKey1")
+	b := New[string, int]()
+	b.Put("key1", 1)
+	patch := Diff(a, b, intEqual)
+
+	// secKey1 points to key2 in the map the patch is applied to, so it is kept.
+	c := New[string, int]()
+	c.Put("key1", 1)
+	c.Put("key2", 2)
+	c.PutSecondaryKeys("key2", "group1", "secKey1")
+	patch.Apply(c)
+	value, exists := c.GetBySecondaryKey("group1", "secKey1")
+	assert.True(t, exists)
+	assert.Equal(t, 2, value)
+}
+
+func TestEqual(t *testing.T) {
+	a := New[string, int]()
+	a.Put("key1", 1)
+	a.PutSecondaryKeys("key1", "group1", "secKey1")
+	b := New[string, int]()
+	b.Put("key1", 1)
+	b.PutSecondaryKeys("key1", "group1", "secKey1")
+	assert.True(t, Equal(a, b, intEqual))
+
+	b.Put("key1", 2)
+	assert.False(t, Equal(a, b, intEqual))
+
+	b.Put("key1", 1)
+	b.PutSecondaryKeys("key1", "group1", "secKey2")
+	assert.False(t, Equal(a, b, intEqual))
+
+	b.Remove("key1")
+	b.Put("key2", 1)
+	assert.False(t, Equal(a, b, intEqual))
+}
@@ -0,0 +1,56 @@
+package multikeymap
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrPrimaryKeyNotFound is returned if a primary key does not exist.
+	ErrPrimaryKeyNotFound = errors.New("primary key does not exist")
+	// ErrPrimaryKeyExists is returned if a primary key already exists.
+	ErrPrimaryKeyExists = errors.New("primary key already exists")
+	// ErrSecondaryKeyConflict is matched by every *ConflictError.
+	ErrSecondaryKeyConflict = errors.New("secondary key already points to a different primary key")
+	// ErrMergeConflict is returned by Merge if the ErrorOnConflict strategy encounters a conflict.
+	ErrMergeConflict = errors.New("merge conflict")
+	// ErrInvalidCursor is returned by Page and GroupPage if the cursor was not created by the same method.
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidLimit is returned by Page and GroupPage if the limit is not greater than zero.
+	ErrInvalidLimit = errors.New("limit must be greater than zero")
+	// ErrInvalidIndexTag is returned by NewIndexed if the mkm struct tags of the value type are invalid.
+	ErrInvalidIndexTag = errors.New("invalid mkm struct tag")
+	// ErrNoInsertionOrder is returned by MoveToFront and MoveToBack if the map was not created with WithInsertionOrder.
+	ErrNoInsertionOrder = errors.New("map was not created with WithInsertionOrder")
+	// ErrNilValue is returned by IndexedMultiKeyMap.Add if the value is a nil pointer.
+	ErrNilValue = errors.New("value is a nil pointer")
+	// ErrNilPrimaryKey is returned by IndexedMultiKeyMap.Add if the primary key type is an interface
+	// and the primary field of the value is nil.
+	ErrNilPrimaryKey = errors.New("primary key is nil")
+	// ErrUncomparablePrimaryKey is returned by IndexedMultiKeyMap.Add if the primary key type is an interface
+	// and the primary field of the value holds a value which is not comparable, like a slice.
+	ErrUncomparablePrimaryKey = errors.New("primary key is not comparable")
+)
+
+// ConflictError is returned if a secondary key already points to a different primary key.
+// It matches ErrSecondaryKeyConflict with errors.Is.
+type ConflictError[K any] struct {
+	// Group and Key are the secondary key which is in conflict.
+	Group string
+	Key   string
+	// PrimaryKey is the primary key the secondary key should have pointed to.
+	PrimaryKey K
+	// ExistingPrimaryKey is the primary key the secondary key already points to.
+	ExistingPrimaryKey K
+}
+
+// Error returns the error message.
+func (e *ConflictError[K]) Error() string {
+	return fmt.Sprintf("secondary key %q in group %q already points to a different primary key %v",
+		e.Key, e.Group, e.ExistingPrimaryKey)
+}
+
+// Unwrap returns ErrSecondaryKeyConflict.
+func (e *ConflictError[K]) Unwrap() error {
+	return ErrSecondaryKeyConflict
+}
@@ -0,0 +1,62 @@
+package multikeymap
+
+import (
+	"hash/maphash"
+	"maps"
+
+	"github.com/aeimer/go-multikeymap/internal/bloom"
+)
+
+// groupFilters are the bloom filters of the secondary keys by group if WithBloomFilter is used.
+type groupFilters struct {
+	seed    maphash.Seed
+	byGroup map[string]*bloom.Filter[string]
+}
+
+func newGroupFilters() *groupFilters {
+	return &groupFilters{seed: maphash.MakeSeed(), byGroup: make(map[string]*bloom.Filter[string])}
+}
+
+func (f *groupFilters) hash(key string) uint64 {
+	return maphash.String(f.seed, key)
+}
+
+// mayContain returns false if the secondary key is definitely not in the group.
+func (m *MultiKeyMap[K, V]) mayContain(group string, key string) bool {
+	if m.filters == nil {
+		return true
+	}
+	filter, exists := m.filters.byGroup[group]
+	return exists && filter.MayContain(key)
+}
+
+// filterAdd adds a secondary key, which was just linked to the group, to the filter of the group.
+func (m *MultiKeyMap[K, V]) filterAdd(group string, key string) {
+	filter, exists := m.filters.byGroup[group]
+	if !exists {
+		filter = bloom.New(m.filters.hash)
+		m.filters.byGroup[group] = filter
+	}
+	filter.Add(key)
+	m.rebuildFilterIfNeeded(group, filter)
+}
+
+// filterRemove records that a secondary key was removed from the group.
+func (m *MultiKeyMap[K, V]) filterRemove(group string) {
+	filter, exists := m.filters.byGroup[group]
+	if !exists {
+		return
+	}
+	if len(m.secondary[group]) == 0 {
+		delete(m.filters.byGroup, group)
+		return
+	}
+	filter.Remove()
+	m.rebuildFilterIfNeeded(group, filter)
+}
+
+func (m *MultiKeyMap[K, V]) rebuildFilterIfNeeded(group string, filter *bloom.Filter[string]) {
+	if filter.NeedsRebuild() {
+		filter.Rebuild(maps.Keys(m.secondary[group]), len(m.secondary[group]))
+	}
+}
@@ -0,0 +1,88 @@
+package multikeymap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiKeyMap_WithBloomFilter(t *testing.T) {
+	mm := New[string, int](WithBloomFilter(), WithInterning())
+	const size = 1000
+	for n := range size {
+		primaryKey := strconv.Itoa(n)
+		mm.Put(primaryKey, n)
+		mm.PutSecondaryKeys(primaryKey, "group1", "a"+primaryKey, "b"+primaryKey)
+	}
+	for n := range size {
+		value, exists := mm.GetBySecondaryKey("group1", "b"+strconv.Itoa(n))
+		require.True(t, exists)
+		require.Equal(t, n, value)
+		require.False(t, mm.HasSecondaryKey("group1", "c"+strconv.Itoa(n)))
+	}
+	assert.False(t, mm.HasSecondaryKey("group2", "a1"))
+
+	// Removing most keys rebuilds the filter, the remaining keys must still be found.
+	for n := range size - 10 {
+		mm.Remove(strconv.Itoa(n))
+	}
+	for n := range size {
+		_, exists := mm.GetBySecondaryKey("group1", "a"+strconv.Itoa(n))
+		assert.Equal(t, n >= size-10, exists)
+	}
+
+	// Moving a key to another primary key keeps it in the filter.
+	mm.PutSecondaryKeys(strconv.Itoa(size-1), "group1", "a"+strconv.Itoa(size-2))
+	value, exists := mm.GetBySecondaryKey("group1", "a"+strconv.Itoa(size-2))
+	assert.True(t, exists)
+	assert.Equal(t, size-1, value)
+
+	for n := size - 10; n < size; n++ {
+		mm.Remove(strconv.Itoa(n))
+	}
+	assert.Empty(t, mm.filters.byGroup)
+	mm.PutSecondaryKeys("key1", "group1", "secKey1")
+	mm.Clear()
+	assert.False(t, mm.HasSecondaryKey("group1", "secKey1"))
+	mm.PutSecondaryKeys("key1", "group1", "secKey1")
+	assert.True(t, mm.HasSecondaryKey("group1", "secKey1"))
+}
+
+// longKey returns a secondary key of about 100 bytes, like a URL or a content hash.
+func longKey(prefix string, n int) string {
+	return prefix + strings.Repeat("x", 90) + strconv.Itoa(n)
+}
+
+func BenchmarkMultiKeyMapGetBySecondaryKeyMiss(b *testing.B) {
+	modes := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "default"},
+		{name: "bloom", opts: []Option{WithBloomFilter()}},
+	}
+	for _, mode := range modes {
+		for _, v := range benchmarkSizes {
+			b.Run(fmt.Sprintf("%s/size_%d", mode.name, v.size), func(b *testing.B) {
+				m := New[string, int](mode.opts...)
+				misses := make([]string, v.size)
+				for n := range v.size {
+					primaryKey := strconv.Itoa(n)
+					m.Put(primaryKey, n)
+					m.PutSecondaryKeys(primaryKey, "url", longKey("hit", n))
+					misses[n] = longKey("miss", n)
+				}
+				b.ResetTimer()
+				for range b.N {
+					for _, key := range misses {
+						m.GetBySecondaryKey("url", key)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package multikeymap
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Groups returns the names of all groups which have at least one secondary key, in lexical order.
+func (m *MultiKeyMap[K, V]) Groups() []string {
+	return slices.Sorted(maps.Keys(m.secondary))
+}
+
+// GroupSize returns the number of secondary keys in a group.
+func (m *MultiKeyMap[K, V]) GroupSize(group string) int {
+	return len(m.secondary[group])
+}
+
+// KeysInGroup returns an iterator over the secondary keys of a group in no particular order.
+// The map must not be modified during the iteration.
+func (m *MultiKeyMap[K, V]) KeysInGroup(group string) iter.Seq[string] {
+	return maps.Keys(m.secondary[group])
+}
+
+// SecondaryKeysOf returns the secondary keys of a primary key by group.
+// The keys of each group are in lexical order. Without secondary keys, the map is empty.
+func (m *MultiKeyMap[K, V]) SecondaryKeysOf(primaryKey K) map[string][]string {
+	result := make(map[string][]string)
+	for group, key := range m.secondaryTo.keys(primaryKey) {
+		result[group] = append(result[group], key)
+	}
+	for _, keys := range result {
+		slices.Sort(keys)
+	}
+	return result
+}
+
+// GroupBy returns the values of all entries with a secondary key in the group, keyed by that secondary key.
+// Secondary keys whose primary key has no value are left out.
+// A secondary key points to a single primary key, so each slice has exactly one element.
+func (m *MultiKeyMap[K, V]) GroupBy(group string) map[string][]V {
+	result := make(map[string][]V, len(m.secondary[group]))
+	for key, primaryKey := range m.secondary[group] {
+		if value, exists := m.primary[primaryKey]; exists {
+			result[key] = append(result[key], value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package multikeymap
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleMultiKeyMap_GroupBy() {
+	mm := New[string, int]()
+	mm.Put("Berlin", 3_500_000)
+	mm.Put("Potsdam", 180_000)
+	mm.PutSecondaryKeys("Berlin", "state", "BE")
+	mm.PutSecondaryKeys("Potsdam", "state", "BB")
+	mm.PutSecondaryKeys("Berlin", "postcode", "10115", "10117")
+
+	fmt.Println(mm.Groups())
+	fmt.Println(mm.GroupSize("postcode"))
+	fmt.Println(mm.SecondaryKeysOf("Berlin"))
+	fmt.Println(mm.GroupBy("state"))
+
+	// Output:
+	// [postcode state]
+	// 2
+	// map[postcode:[10115 10117] state:[BE]]
+	// map[BB:[180000] BE:[3500000]]
+}
+
+func newGroupsTestMap(opts ...Option) *MultiKeyMap[string, int] {
+	mm := New[string, int](opts...)
+	mm.Put("key1", 1)
+	mm.Put("key2", 2)
+	mm.PutSecondaryKeys("key1", "group1", "secKey2", "secKey1")
+	mm.PutSecondaryKeys("key1", "group2", "secKey3")
+	mm.PutSecondaryKeys("key2", "group1", "secKey4")
+	// key3 has secondary keys, but no value.
+	mm.PutSecondaryKeys("key3", "group2", "secKey5")
+	return mm
+}
+
+func TestMultiKeyMap_GroupBy_OneValuePerKey(t *testing.T) {
+	mm := New[string, int]()
+	mm.Put("key1", 1)
+	mm.Put("key2", 2)
+	mm.PutSecondaryKeys("key1", "group1", "secKey1", "secKey2")
+	mm.PutSecondaryKeys("key2", "group1", "secKey1")
+	mm.PutSecondaryKeys("key3", "group1", "secKey3")
+
+	groups := mm.GroupBy("group1")
+	assert.Equal(t, map[string][]int{"secKey1": {2}, "secKey2": {1}}, groups)
+	for key, values := range groups {
+		assert.Len(t, values, 1, key)
+	}
+}
+
+func TestMultiKeyMap_Groups(t *testing.T) {
+	for _, mode := range benchmarkModes {
+		if mode.slab {
+			continue // See TestSlabMultiKeyMap_Groups.
+		}
+		t.Run(mode.name, func(t *testing.T) {
+			mm := newGroupsTestMap(mode.opts...)
+			assert.Equal(t, []string{"group1", "group2"}, mm.Groups())
+			assert.Equal(t, 3, mm.GroupSize("group1"))
+			assert.Equal(t, 2, mm.GroupSize("group2"))
+			assert.Equal(t, 0, mm.GroupSize("group3"))
+			assert.ElementsMatch(t, []string{"secKey1", "secKey2", "secKey4"}, slices.Collect(mm.KeysInGroup("group1")))
+			assert.Empty(t, slices.Collect(mm.KeysInGroup("group3")))
+
+			assert.Equal(t, map[string][]string{"group1": {"secKey1", "secKey2"}, "group2": {"secKey3"}}, mm.SecondaryKeysOf("key1"))
+			assert.Equal(t, map[string][]string{"group2": {"secKey5"}}, mm.SecondaryKeysOf("key3"))
+			assert.Empty(t, mm.SecondaryKeysOf("key4"))
+
+			assert.Equal(t, map[string][]int{"secKey1": {1}, "secKey2": {1}, "secKey4": {2}}, mm.GroupBy("group1"))
+			assert.Equal(t, map[string][]int{"secKey3": {1}}, mm.GroupBy("group2"))
+			assert.Empty(t, mm.GroupBy("group3"))
+
+			mm.Remove("key1")
+			assert.Equal(t, []string{"group1", "group2"}, mm.Groups())
+			assert.Equal(t, 1, mm.GroupSize("group1"))
+			mm.Remove("key3")
+			assert.Equal(t, []string{"group1"}, mm.Groups())
+		})
+	}
+}
+
+func BenchmarkMultiKeyMapGroups(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		m := New[string, int]()
+		putBenchmarkSecondaryKeys(m, v.size)
+		b.Run(fmt.Sprintf("GetAllKeyGroups/size_%d", v.size), func(b *testing.B) {
+			for range b.N {
+				groups := m.GetAllKeyGroups()
+				_ = len(groups["postcode"])
+			}
+		})
+		b.Run(fmt.Sprintf("GroupSize/size_%d", v.size), func(b *testing.B) {
+			for range b.N {
+				for _, group := range m.Groups() {
+					_ = m.GroupSize(group)
+				}
+			}
+		})
+	}
+}
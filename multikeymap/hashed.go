@@ -0,0 +1,292 @@
+package multikeymap
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/aeimer/go-multikeymap/internal/keyindex"
+)
+
+// HashedMultiKeyMap is the same as MultiKeyMap, but the primary keys are compared
+// with a user-supplied hash and equality function instead of ==.
+// Therefore, the primary keys can be of any type, e.g. []byte, or be canonicalized, e.g. case-insensitive strings.
+// Primary keys must not be modified after they were put.
+// If equal primary keys are put, the first one is kept and returned by the map.
+// HashedMultiKeyMap is not safe for concurrent use.
+type HashedMultiKeyMap[K any, V any] struct {
+	keys *keyindex.Index[K]
+	base MultiKeyMap[keyindex.ID, V]
+}
+
+// NewWithHasher creates a new HashedMultiKeyMap instance.
+// Primary keys which are equal according to eq must have the same hash.
+// Primary keys with the same hash are stored in one bucket and compared with eq.
+func NewWithHasher[K any, V any](hash func(K) uint64, eq func(K, K) bool, opts ...Option) *HashedMultiKeyMap[K, V] {
+	return &HashedMultiKeyMap[K, V]{
+		keys: keyindex.New(hash, eq),
+		base: *New[keyindex.ID, V](opts...),
+	}
+}
+
+// Put inserts a value with a primary key.
+func (m *HashedMultiKeyMap[K, V]) Put(primaryKey K, value V) {
+	id, _ := m.keys.Add(primaryKey)
+	m.base.Put(id, value)
+}
+
+// PutSecondaryKeys adds secondary keys under a group for a primary key.
+// A secondary key which already points to another primary key is moved to the given primary key.
+func (m *HashedMultiKeyMap[K, V]) PutSecondaryKeys(primaryKey K, group string, keys ...string) {
+	id, _ := m.keys.Add(primaryKey)
+	owners := make([]keyindex.ID, 0, len(keys))
+	for _, key := range keys {
+		if owner, exists := m.base.secondary[group][key]; exists && owner != id {
+			owners = append(owners, owner)
+		}
+	}
+	m.base.PutSecondaryKeys(id, group, keys...)
+	for _, owner := range owners {
+		m.release(owner)
+	}
+}
+
+// RemoveSecondaryKeys removes secondary keys from a group if they point to the given primary key.
+// Secondary keys which do not exist or point to another primary key are not changed.
+func (m *HashedMultiKeyMap[K, V]) RemoveSecondaryKeys(primaryKey K, group string, keys ...string) {
+	id, exists := m.keys.Lookup(primaryKey)
+	if !exists {
+		return
+	}
+	m.base.RemoveSecondaryKeys(id, group, keys...)
+	m.release(id)
+}
+
+// release deletes the ID of a primary key which has neither a value nor secondary keys anymore.
+func (m *HashedMultiKeyMap[K, V]) release(id keyindex.ID) {
+	if _, exists := m.base.primary[id]; exists {
+		return
+	}
+	if m.base.secondaryTo.has(id) {
+		return
+	}
+	m.keys.Delete(id)
+}
+
+// HasPrimaryKey checks if a primary key exists.
+func (m *HashedMultiKeyMap[K, V]) HasPrimaryKey(primaryKey K) bool {
+	id, exists := m.keys.Lookup(primaryKey)
+	return exists && m.base.HasPrimaryKey(id)
+}
+
+// HasSecondaryKey checks if a secondary key exists in a specific group.
+func (m *HashedMultiKeyMap[K, V]) HasSecondaryKey(group string, key string) bool {
+	return m.base.HasSecondaryKey(group, key)
+}
+
+// GetAllKeyGroups returns all key groups and their secondary keys.
+func (m *HashedMultiKeyMap[K, V]) GetAllKeyGroups() map[string]map[string]K {
+	result := make(map[string]map[string]K, len(m.base.secondary))
+	for group, keys := range m.base.secondary {
+		result[group] = make(map[string]K, len(keys))
+		for key, id := range keys {
+			result[group][key] = m.keys.Key(id)
+		}
+	}
+	return result
+}
+
+// Remove removes a primary key and its associated secondary keys.
+func (m *HashedMultiKeyMap[K, V]) Remove(primaryKey K) {
+	id, exists := m.keys.Lookup(primaryKey)
+	if !exists {
+		return
+	}
+	m.base.Remove(id)
+	m.keys.Delete(id)
+}
+
+// RekeyPrimary moves the value and all secondary keys of oldPrimaryKey to newPrimaryKey.
+// The entry keeps its position in the insertion order.
+// It returns ErrPrimaryKeyNotFound if oldPrimaryKey does not exist
+// and ErrPrimaryKeyExists if newPrimaryKey already exists.
+func (m *HashedMultiKeyMap[K, V]) RekeyPrimary(oldPrimaryKey K, newPrimaryKey K) error {
+	oldID, exists := m.keys.Lookup(oldPrimaryKey)
+	if !exists || !m.base.HasPrimaryKey(oldID) {
+		return ErrPrimaryKeyNotFound
+	}
+	newID, added := m.keys.Add(newPrimaryKey)
+	if err := m.base.RekeyPrimary(oldID, newID); err != nil {
+		if added {
+			m.keys.Delete(newID)
+		}
+		return err
+	}
+	if newID != oldID {
+		m.keys.Delete(oldID)
+	}
+	return nil
+}
+
+// Get returns a value by primary key.
+func (m *HashedMultiKeyMap[K, V]) Get(primaryKey K) (V, bool) {
+	id, exists := m.keys.Lookup(primaryKey)
+	if !exists {
+		var zero V
+		return zero, false
+	}
+	return m.base.Get(id)
+}
+
+// GetBySecondaryKey returns a primary key by secondary key and group.
+func (m *HashedMultiKeyMap[K, V]) GetBySecondaryKey(group string, key string) (V, bool) {
+	return m.base.GetBySecondaryKey(group, key)
+}
+
+// Groups returns the names of all groups which have at least one secondary key, in lexical order.
+func (m *HashedMultiKeyMap[K, V]) Groups() []string {
+	return m.base.Groups()
+}
+
+// GroupSize returns the number of secondary keys in a group.
+func (m *HashedMultiKeyMap[K, V]) GroupSize(group string) int {
+	return m.base.GroupSize(group)
+}
+
+// KeysInGroup returns an iterator over the secondary keys of a group in no particular order.
+// The map must not be modified during the iteration.
+func (m *HashedMultiKeyMap[K, V]) KeysInGroup(group string) iter.Seq[string] {
+	return m.base.KeysInGroup(group)
+}
+
+// SecondaryKeysOf returns the secondary keys of a primary key by group.
+// The keys of each group are in lexical order. Without secondary keys, the map is empty.
+func (m *HashedMultiKeyMap[K, V]) SecondaryKeysOf(primaryKey K) map[string][]string {
+	id, exists := m.keys.Lookup(primaryKey)
+	if !exists {
+		return make(map[string][]string)
+	}
+	return m.base.SecondaryKeysOf(id)
+}
+
+// GroupBy returns the values of all entries with a secondary key in the group, keyed by that secondary key.
+// Secondary keys whose primary key has no value are left out.
+// A secondary key points to a single primary key, so each slice has exactly one element.
+func (m *HashedMultiKeyMap[K, V]) GroupBy(group string) map[string][]V {
+	return m.base.GroupBy(group)
+}
+
+// Query returns an iterator over all entries matching the query.
+func (m *HashedMultiKeyMap[K, V]) Query(q Query) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for id, value := range m.base.Query(q) {
+			if !yield(m.keys.Key(id), value) {
+				return
+			}
+		}
+	}
+}
+
+// Page returns up to limit entries following the cursor in insertion order, and the cursor for the next page.
+func (m *HashedMultiKeyMap[K, V]) Page(cursor Cursor, limit int) ([]Entry[K, V], Cursor, error) {
+	page, next, err := m.base.Page(cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	entries := make([]Entry[K, V], 0, len(page))
+	for _, entry := range page {
+		entries = append(entries, Entry[K, V]{PrimaryKey: m.keys.Key(entry.PrimaryKey), Value: entry.Value})
+	}
+	return entries, next, nil
+}
+
+// GroupPage returns up to limit secondary keys of the group following the cursor together with their entries,
+// ordered by the secondary key, and the cursor for the next page.
+func (m *HashedMultiKeyMap[K, V]) GroupPage(group string, cursor Cursor, limit int) ([]GroupEntry[K, V], Cursor, error) {
+	page, next, err := m.base.GroupPage(group, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	entries := make([]GroupEntry[K, V], 0, len(page))
+	for _, entry := range page {
+		entries = append(entries, GroupEntry[K, V]{Key: entry.Key, PrimaryKey: m.keys.Key(entry.PrimaryKey), Value: entry.Value})
+	}
+	return entries, next, nil
+}
+
+// Keys returns a slice of all primary keys in the map.
+func (m *HashedMultiKeyMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.base.Size())
+	for id := range m.base.All() {
+		keys = append(keys, m.keys.Key(id))
+	}
+	return keys
+}
+
+// MoveToFront moves an entry to the front of the insertion order.
+// It returns ErrNoInsertionOrder if the map was not created with WithInsertionOrder
+// and ErrPrimaryKeyNotFound if the primary key does not exist.
+func (m *HashedMultiKeyMap[K, V]) MoveToFront(primaryKey K) error {
+	id, exists := m.keys.Lookup(primaryKey)
+	if !exists && m.base.insertionOrder {
+		return ErrPrimaryKeyNotFound
+	}
+	return m.base.MoveToFront(id)
+}
+
+// MoveToBack moves an entry to the back of the insertion order.
+// It returns ErrNoInsertionOrder if the map was not created with WithInsertionOrder
+// and ErrPrimaryKeyNotFound if the primary key does not exist.
+func (m *HashedMultiKeyMap[K, V]) MoveToBack(primaryKey K) error {
+	id, exists := m.keys.Lookup(primaryKey)
+	if !exists && m.base.insertionOrder {
+		return ErrPrimaryKeyNotFound
+	}
+	return m.base.MoveToBack(id)
+}
+
+// Has checks if a primary key exists. It is the same as HasPrimaryKey.
+func (m *HashedMultiKeyMap[K, V]) Has(primaryKey K) bool {
+	return m.HasPrimaryKey(primaryKey)
+}
+
+// All returns an iterator over all primary keys and their values.
+func (m *HashedMultiKeyMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for id, value := range m.base.All() {
+			if !yield(m.keys.Key(id), value) {
+				return
+			}
+		}
+	}
+}
+
+// Size returns the number of elements in the map.
+func (m *HashedMultiKeyMap[K, V]) Size() int {
+	return m.base.Size()
+}
+
+// Empty checks if the map is empty.
+func (m *HashedMultiKeyMap[K, V]) Empty() bool {
+	return m.base.Empty()
+}
+
+// Values returns a slice of all values in the map.
+func (m *HashedMultiKeyMap[K, V]) Values() []V {
+	return m.base.Values()
+}
+
+// Clear removes all elements from the map.
+func (m *HashedMultiKeyMap[K, V]) Clear() {
+	m.base.Clear()
+	m.keys.Clear()
+}
+
+// String returns a string representation of the map.
+// With WithInsertionOrder, the entries are listed in insertion order.
+func (m *HashedMultiKeyMap[K, V]) String() string {
+	entries := make([]Entry[K, V], 0, m.base.Size())
+	for id, value := range m.base.All() {
+		entries = append(entries, Entry[K, V]{PrimaryKey: m.keys.Key(id), Value: value})
+	}
+	return fmt.Sprintf("HashedMultiKeyMap: %v", entries)
+}
@@ -0,0 +1,163 @@
+package multikeymap
+
+import (
+	"bytes"
+	"fmt"
+	"hash/maphash"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSeed = maphash.MakeSeed()
+
+func hashBytes(key []byte) uint64 {
+	return maphash.Bytes(testSeed, key)
+}
+
+// collidingHash puts all keys into the same bucket.
+func collidingHash([]byte) uint64 {
+	return 42
+}
+
+func ExampleNewWithHasher() {
+	// Case-insensitive primary keys.
+	mm := NewWithHasher[string, int](
+		func(key string) uint64 { return maphash.String(testSeed, strings.ToLower(key)) },
+		strings.EqualFold,
+	)
+	mm.Put("Berlin", 3_500_000)
+	mm.PutSecondaryKeys("BERLIN", "postcode", "10115")
+
+	value, _ := mm.Get("berlin")
+	fmt.Println(value)
+	value, _ = mm.GetBySecondaryKey("postcode", "10115")
+	fmt.Println(value)
+	fmt.Println(mm.GetAllKeyGroups())
+	// Output:
+	// 3500000
+	// 3500000
+	// map[postcode:map[10115:Berlin]]
+}
+
+func TestHashedMultiKeyMap_ImplementsContainerInterface(t *testing.T) {
+	instance := NewWithHasher[[]byte, int](hashBytes, bytes.Equal)
+	if _, ok := any(instance).(container.KeyedContainer[[]byte, int]); !ok {
+		t.Error("HashedMultiKeyMap does not implement the KeyedContainer interface")
+	}
+}
+
+func TestHashedMultiKeyMap(t *testing.T) {
+	for name, hash := range map[string]func([]byte) uint64{"Hash": hashBytes, "Collisions": collidingHash} {
+		t.Run(name, func(t *testing.T) {
+			mm := NewWithHasher[[]byte, int](hash, bytes.Equal)
+			mm.Put([]byte("key1"), 1)
+			mm.Put([]byte("key2"), 2)
+			mm.Put([]byte("key1"), 10)
+			mm.PutSecondaryKeys([]byte("key1"), "group1", "secKey1")
+			assert.Equal(t, 2, mm.Size())
+
+			value, exists := mm.Get([]byte("key1"))
+			assert.True(t, exists)
+			assert.Equal(t, 10, value)
+			value, exists = mm.GetBySecondaryKey("group1", "secKey1")
+			assert.True(t, exists)
+			assert.Equal(t, 10, value)
+			assert.True(t, mm.Has([]byte("key2")))
+			assert.False(t, mm.Has([]byte("key3")))
+			assert.ElementsMatch(t, [][]byte{[]byte("key1"), []byte("key2")}, mm.Keys())
+
+			require.NoError(t, mm.RekeyPrimary([]byte("key1"), []byte("key3")))
+			require.ErrorIs(t, mm.RekeyPrimary([]byte("key1"), []byte("key4")), ErrPrimaryKeyNotFound)
+			require.ErrorIs(t, mm.RekeyPrimary([]byte("key3"), []byte("key2")), ErrPrimaryKeyExists)
+			assert.Equal(t, map[string]map[string][]byte{"group1": {"secKey1": []byte("key3")}}, mm.GetAllKeyGroups())
+
+			mm.Remove([]byte("key3"))
+			assert.False(t, mm.HasSecondaryKey("group1", "secKey1"))
+			assert.Equal(t, 1, mm.Size())
+			assert.Equal(t, 1, mm.keys.Len())
+
+			mm.Clear()
+			assert.True(t, mm.Empty())
+			assert.Equal(t, 0, mm.keys.Len())
+		})
+	}
+}
+
+func TestHashedMultiKeyMap_ReleasesKeys(t *testing.T) {
+	mm := NewWithHasher[[]byte, int](hashBytes, bytes.Equal)
+	mm.PutSecondaryKeys([]byte("key1"), "group1", "secKey1")
+	mm.Put([]byte("key2"), 2)
+	assert.Equal(t, 2, mm.keys.Len())
+
+	// key1 has neither a value nor secondary keys anymore.
+	mm.PutSecondaryKeys([]byte("key2"), "group1", "secKey1")
+	assert.Equal(t, 1, mm.keys.Len())
+	value, _ := mm.GetBySecondaryKey("group1", "secKey1")
+	assert.Equal(t, 2, value)
+
+	// key3 has no value, so removing its only secondary key releases it.
+	mm.PutSecondaryKeys([]byte("key3"), "group1", "secKey3")
+	assert.Equal(t, 2, mm.keys.Len())
+	mm.RemoveSecondaryKeys([]byte("key3"), "group1", "secKey3")
+	assert.Equal(t, 1, mm.keys.Len())
+	assert.False(t, mm.HasSecondaryKey("group1", "secKey3"))
+}
+
+func TestHashedMultiKeyMap_WithInsertionOrder(t *testing.T) {
+	mm := NewWithHasher[[]byte, int](hashBytes, bytes.Equal, WithInsertionOrder())
+	mm.Put([]byte("key2"), 2)
+	mm.Put([]byte("key1"), 1)
+	mm.Put([]byte("key3"), 3)
+	require.NoError(t, mm.MoveToFront([]byte("key3")))
+	require.NoError(t, mm.MoveToBack([]byte("key2")))
+	assert.Equal(t, [][]byte{[]byte("key3"), []byte("key1"), []byte("key2")}, mm.Keys())
+	assert.Equal(t, []int{3, 1, 2}, mm.Values())
+	require.ErrorIs(t, mm.MoveToFront([]byte("key4")), ErrPrimaryKeyNotFound)
+	require.ErrorIs(t, NewWithHasher[[]byte, int](hashBytes, bytes.Equal).MoveToBack([]byte("key1")), ErrNoInsertionOrder)
+}
+
+func TestHashedMultiKeyMap_Groups(t *testing.T) {
+	mm := NewWithHasher[[]byte, int](hashBytes, bytes.Equal)
+	mm.Put([]byte("key1"), 1)
+	mm.PutSecondaryKeys([]byte("key1"), "group1", "secKey2", "secKey1")
+	assert.Equal(t, []string{"group1"}, mm.Groups())
+	assert.Equal(t, 2, mm.GroupSize("group1"))
+	assert.ElementsMatch(t, []string{"secKey1", "secKey2"}, slices.Collect(mm.KeysInGroup("group1")))
+	assert.Equal(t, map[string][]string{"group1": {"secKey1", "secKey2"}}, mm.SecondaryKeysOf([]byte("key1")))
+	assert.Empty(t, mm.SecondaryKeysOf([]byte("key2")))
+	assert.Equal(t, map[string][]int{"secKey1": {1}, "secKey2": {1}}, mm.GroupBy("group1"))
+}
+
+func TestHashedMultiKeyMap_QueryAndPage(t *testing.T) {
+	mm := NewWithHasher[[]byte, int](hashBytes, bytes.Equal)
+	for n := range 3 {
+		key := []byte(fmt.Sprintf("key%d", n))
+		mm.Put(key, n)
+		mm.PutSecondaryKeys(key, "group1", fmt.Sprintf("secKey%d", n))
+	}
+
+	for primaryKey, value := range mm.Query(Eq("group1", "secKey1")) {
+		assert.Equal(t, []byte("key1"), primaryKey)
+		assert.Equal(t, 1, value)
+	}
+	entries, cursor, err := mm.Page("", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry[[]byte, int]{{[]byte("key0"), 0}, {[]byte("key1"), 1}}, entries)
+	entries, _, err = mm.Page(cursor, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry[[]byte, int]{{[]byte("key2"), 2}}, entries)
+
+	groupEntries, _, err := mm.GroupPage("group1", "", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []GroupEntry[[]byte, int]{{"secKey0", []byte("key0"), 0}}, groupEntries)
+	// Without WithInsertionOrder, String lists the entries in no particular order.
+	assert.True(t, strings.HasPrefix(mm.String(), "HashedMultiKeyMap: ["))
+	for _, entry := range []string{"{[107 101 121 48] 0}", "{[107 101 121 49] 1}", "{[107 101 121 50] 2}"} {
+		assert.Contains(t, mm.String(), entry)
+	}
+}
@@ -0,0 +1,361 @@
+package multikeymap
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// IndexedMultiKeyMap is a MultiKeyMap for struct values, which takes the primary key and the secondary keys
+// from the fields of the values. The fields are selected with mkm struct tags:
+//
+//	type City struct {
+//		Name     string   `mkm:"primary"`
+//		Postcode string   `mkm:"group=postcode,omitempty"`
+//		Aliases  []string `mkm:"group=alias,multi"`
+//	}
+//
+// Exactly one field must be tagged with primary and its type must be K.
+// A field tagged with group=<name> is a secondary key of the group.
+// It must be a string or an integer; integers are formatted with strconv.
+// With multi, the field must be a slice or array of these types and every element is a secondary key.
+// With omitempty, empty strings and zero values are not indexed.
+// Only the fields of the struct itself are read, fields of embedded structs are not.
+// IndexedMultiKeyMap is not safe for concurrent use.
+type IndexedMultiKeyMap[K comparable, V any] struct {
+	index structIndex
+	base  MultiKeyMap[K, V]
+}
+
+// structIndex holds the fields of a struct type selected by mkm struct tags.
+type structIndex struct {
+	pointer bool // Whether the values are pointers to the struct
+	primary int
+	groups  []groupField
+}
+
+// groupField is a struct field which holds the secondary keys of a group.
+type groupField struct {
+	index     int
+	group     string
+	multi     bool
+	omitEmpty bool
+}
+
+// NewIndexed creates a new IndexedMultiKeyMap instance.
+// V must be a struct or a pointer to a struct.
+// It returns an error matching ErrInvalidIndexTag if the mkm struct tags of V are invalid.
+func NewIndexed[K comparable, V any](opts ...Option) (*IndexedMultiKeyMap[K, V], error) {
+	index, err := newStructIndex(reflect.TypeFor[V](), reflect.TypeFor[K]())
+	if err != nil {
+		return nil, err
+	}
+	return &IndexedMultiKeyMap[K, V]{
+		index: index,
+		base:  *New[K, V](opts...),
+	}, nil
+}
+
+// newStructIndex reads the mkm struct tags of the value type.
+func newStructIndex(valueType reflect.Type, keyType reflect.Type) (structIndex, error) {
+	var index structIndex
+	structType := valueType
+	if structType.Kind() == reflect.Pointer {
+		index.pointer = true
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return index, fmt.Errorf("%w: %v is not a struct or a pointer to a struct", ErrInvalidIndexTag, valueType)
+	}
+
+	index.primary = -1
+	groups := make(map[string]string)
+	for i := range structType.NumField() {
+		field := structType.Field(i)
+		tag, ok := field.Tag.Lookup("mkm")
+		if !ok {
+			continue
+		}
+		if !field.IsExported() {
+			return index, fmt.Errorf("%w: field %s is not exported", ErrInvalidIndexTag, field.Name)
+		}
+
+		if tag == "primary" {
+			if index.primary >= 0 {
+				return index, fmt.Errorf("%w: fields %s and %s are both tagged with primary",
+					ErrInvalidIndexTag, structType.Field(index.primary).Name, field.Name)
+			}
+			if field.Type != keyType {
+				return index, fmt.Errorf("%w: primary field %s has type %v, but the primary key type is %v",
+					ErrInvalidIndexTag, field.Name, field.Type, keyType)
+			}
+			if !field.Type.Comparable() {
+				return index, fmt.Errorf("%w: primary field %s of type %v is not comparable",
+					ErrInvalidIndexTag, field.Name, field.Type)
+			}
+			index.primary = i
+			continue
+		}
+
+		groupField, err := parseGroupTag(field, tag)
+		if err != nil {
+			return index, err
+		}
+		if other, exists := groups[groupField.group]; exists {
+			return index, fmt.Errorf("%w: fields %s and %s are both tagged with group %s",
+				ErrInvalidIndexTag, other, field.Name, groupField.group)
+		}
+		groups[groupField.group] = field.Name
+		index.groups = append(index.groups, groupField)
+	}
+	if index.primary < 0 {
+		return index, fmt.Errorf("%w: no field of %v is tagged with primary", ErrInvalidIndexTag, structType)
+	}
+	return index, nil
+}
+
+// parseGroupTag parses a tag like group=alias,multi of a field.
+func parseGroupTag(field reflect.StructField, tag string) (groupField, error) {
+	result := groupField{index: field.Index[0]}
+	name, options, _ := strings.Cut(tag, ",")
+	group, ok := strings.CutPrefix(name, "group=")
+	if !ok || group == "" {
+		return result, fmt.Errorf("%w: field %s has tag %q, expected primary or group=<name>", ErrInvalidIndexTag, field.Name, tag)
+	}
+	result.group = group
+	if options != "" {
+		for _, option := range strings.Split(options, ",") {
+			switch option {
+			case "multi":
+				result.multi = true
+			case "omitempty":
+				result.omitEmpty = true
+			default:
+				return result, fmt.Errorf("%w: field %s has unknown option %q", ErrInvalidIndexTag, field.Name, option)
+			}
+		}
+	}
+
+	keyType := field.Type
+	if result.multi {
+		if keyType.Kind() != reflect.Slice && keyType.Kind() != reflect.Array {
+			return result, fmt.Errorf("%w: field %s of type %v is tagged with multi, but is not a slice or array",
+				ErrInvalidIndexTag, field.Name, field.Type)
+		}
+		keyType = keyType.Elem()
+	}
+	if !isSecondaryKeyKind(keyType.Kind()) {
+		if !result.multi && (keyType.Kind() == reflect.Slice || keyType.Kind() == reflect.Array) {
+			return result, fmt.Errorf("%w: field %s of type %v is a slice or array, but is not tagged with multi",
+				ErrInvalidIndexTag, field.Name, field.Type)
+		}
+		return result, fmt.Errorf("%w: field %s has unsupported type %v, expected a string or an integer",
+			ErrInvalidIndexTag, field.Name, field.Type)
+	}
+	return result, nil
+}
+
+// isSecondaryKeyKind checks if values of the kind can be formatted as secondary keys.
+func isSecondaryKeyKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+// formatSecondaryKey formats a field value as secondary key. The kind was checked by isSecondaryKeyKind.
+func formatSecondaryKey(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.String:
+		return value.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	default:
+		return strconv.FormatUint(value.Uint(), 10)
+	}
+}
+
+// secondaryKeys returns the secondary keys of every group of a struct value in the order of the fields.
+func (idx structIndex) secondaryKeys(value reflect.Value) [][]string {
+	result := make([][]string, len(idx.groups))
+	for i, field := range idx.groups {
+		fieldValue := value.Field(field.index)
+		if !field.multi {
+			if !field.omitEmpty || !fieldValue.IsZero() {
+				result[i] = append(result[i], formatSecondaryKey(fieldValue))
+			}
+			continue
+		}
+		for j := range fieldValue.Len() {
+			if element := fieldValue.Index(j); !field.omitEmpty || !element.IsZero() {
+				result[i] = append(result[i], formatSecondaryKey(element))
+			}
+		}
+	}
+	return result
+}
+
+// Add inserts or replaces a value and indexes its tagged fields.
+// The secondary keys of a replaced value which are not tagged anymore are removed.
+// It returns ErrNilValue if the value is a nil pointer, ErrNilPrimaryKey if K is an interface
+// and the primary field is nil, ErrUncomparablePrimaryKey if the primary field holds a value which is not comparable,
+// and a *ConflictError if a secondary key already points to a different primary key.
+// The map is not changed if an error is returned.
+//
+// The fields are only read by Add. If V is a pointer and the struct is changed afterwards,
+// the indexes are stale until the value is added again.
+func (m *IndexedMultiKeyMap[K, V]) Add(value V) error {
+	structValue := reflect.ValueOf(&value).Elem()
+	if m.index.pointer {
+		if structValue.IsNil() {
+			return ErrNilValue
+		}
+		structValue = structValue.Elem()
+	}
+	// An interface type is comparable, but the value it holds may not be, and hashing it would panic.
+	primaryField := structValue.Field(m.index.primary)
+	if !primaryField.Comparable() {
+		return ErrUncomparablePrimaryKey
+	}
+	primaryKey, ok := primaryField.Interface().(K)
+	if !ok {
+		return ErrNilPrimaryKey
+	}
+	secondaryKeys := m.index.secondaryKeys(structValue)
+	for i, keys := range secondaryKeys {
+		group := m.index.groups[i].group
+		for _, key := range keys {
+			if owner, exists := m.base.secondary[group][key]; exists && owner != primaryKey {
+				return &ConflictError[K]{Group: group, Key: key, PrimaryKey: primaryKey, ExistingPrimaryKey: owner}
+			}
+		}
+	}
+
+	m.base.removeSecondaryKeysOf(primaryKey)
+	m.base.Put(primaryKey, value)
+	for i, keys := range secondaryKeys {
+		m.base.PutSecondaryKeys(primaryKey, m.index.groups[i].group, keys...)
+	}
+	return nil
+}
+
+// HasPrimaryKey checks if a primary key exists.
+func (m *IndexedMultiKeyMap[K, V]) HasPrimaryKey(primaryKey K) bool {
+	return m.base.HasPrimaryKey(primaryKey)
+}
+
+// HasSecondaryKey checks if a secondary key exists in a specific group.
+func (m *IndexedMultiKeyMap[K, V]) HasSecondaryKey(group string, key string) bool {
+	return m.base.HasSecondaryKey(group, key)
+}
+
+// GetAllKeyGroups returns all key groups and their secondary keys.
+func (m *IndexedMultiKeyMap[K, V]) GetAllKeyGroups() map[string]map[string]K {
+	return m.base.GetAllKeyGroups()
+}
+
+// Remove removes a primary key and its associated secondary keys.
+func (m *IndexedMultiKeyMap[K, V]) Remove(primaryKey K) {
+	m.base.Remove(primaryKey)
+}
+
+// Get returns a value by primary key.
+func (m *IndexedMultiKeyMap[K, V]) Get(primaryKey K) (V, bool) {
+	return m.base.Get(primaryKey)
+}
+
+// GetBySecondaryKey returns a primary key by secondary key and group.
+func (m *IndexedMultiKeyMap[K, V]) GetBySecondaryKey(group string, key string) (V, bool) {
+	return m.base.GetBySecondaryKey(group, key)
+}
+
+// Groups returns the names of all groups which have at least one secondary key, in lexical order.
+func (m *IndexedMultiKeyMap[K, V]) Groups() []string {
+	return m.base.Groups()
+}
+
+// GroupSize returns the number of secondary keys in a group.
+func (m *IndexedMultiKeyMap[K, V]) GroupSize(group string) int {
+	return m.base.GroupSize(group)
+}
+
+// KeysInGroup returns an iterator over the secondary keys of a group in no particular order.
+// The map must not be modified during the iteration.
+func (m *IndexedMultiKeyMap[K, V]) KeysInGroup(group string) iter.Seq[string] {
+	return m.base.KeysInGroup(group)
+}
+
+// SecondaryKeysOf returns the secondary keys of a primary key by group.
+// The keys of each group are in lexical order. Without secondary keys, the map is empty.
+func (m *IndexedMultiKeyMap[K, V]) SecondaryKeysOf(primaryKey K) map[string][]string {
+	return m.base.SecondaryKeysOf(primaryKey)
+}
+
+// GroupBy returns the values of all entries with a secondary key in the group, keyed by that secondary key.
+// Secondary keys whose primary key has no value are left out.
+// A secondary key points to a single primary key, so each slice has exactly one element.
+func (m *IndexedMultiKeyMap[K, V]) GroupBy(group string) map[string][]V {
+	return m.base.GroupBy(group)
+}
+
+// Query returns an iterator over all entries matching the query.
+func (m *IndexedMultiKeyMap[K, V]) Query(q Query) iter.Seq2[K, V] {
+	return m.base.Query(q)
+}
+
+// Page returns up to limit entries following the cursor in insertion order, and the cursor for the next page.
+func (m *IndexedMultiKeyMap[K, V]) Page(cursor Cursor, limit int) ([]Entry[K, V], Cursor, error) {
+	return m.base.Page(cursor, limit)
+}
+
+// GroupPage returns up to limit secondary keys of the group following the cursor together with their entries,
+// ordered by the secondary key, and the cursor for the next page.
+func (m *IndexedMultiKeyMap[K, V]) GroupPage(group string, cursor Cursor, limit int) ([]GroupEntry[K, V], Cursor, error) {
+	return m.base.GroupPage(group, cursor, limit)
+}
+
+// Keys returns a slice of all primary keys in the map.
+func (m *IndexedMultiKeyMap[K, V]) Keys() []K {
+	return m.base.Keys()
+}
+
+// Has checks if a primary key exists. It is the same as HasPrimaryKey.
+func (m *IndexedMultiKeyMap[K, V]) Has(primaryKey K) bool {
+	return m.base.Has(primaryKey)
+}
+
+// All returns an iterator over all primary keys and their values.
+func (m *IndexedMultiKeyMap[K, V]) All() iter.Seq2[K, V] {
+	return m.base.All()
+}
+
+// Size returns the number of elements in the map.
+func (m *IndexedMultiKeyMap[K, V]) Size() int {
+	return m.base.Size()
+}
+
+// Empty checks if the map is empty.
+func (m *IndexedMultiKeyMap[K, V]) Empty() bool {
+	return m.base.Empty()
+}
+
+// Values returns a slice of all values in the map.
+func (m *IndexedMultiKeyMap[K, V]) Values() []V {
+	return m.base.Values()
+}
+
+// Clear removes all elements from the map.
+func (m *IndexedMultiKeyMap[K, V]) Clear() {
+	m.base.Clear()
+}
+
+// String returns a string representation of the map.
+func (m *IndexedMultiKeyMap[K, V]) String() string {
+	return "Indexed" + m.base.String()
+}
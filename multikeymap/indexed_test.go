@@ -0,0 +1,296 @@
+package multikeymap
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type indexedCity struct {
+	Name       string   `mkm:"primary"`
+	Postcode   string   `mkm:"group=postcode,omitempty"`
+	Aliases    []string `mkm:"group=alias,multi"`
+	AreaCodes  [2]int   `mkm:"group=areacode,multi,omitempty"`
+	ZIP        uint16   `mkm:"group=zip,omitempty"`
+	Population int
+}
+
+func ExampleNewIndexed() {
+	type City struct {
+		Name     string   `mkm:"primary"`
+		Postcode string   `mkm:"group=postcode"`
+		Aliases  []string `mkm:"group=alias,multi"`
+	}
+	mm, err := NewIndexed[string, City]()
+	if err != nil {
+		panic(err)
+	}
+	_ = mm.Add(City{Name: "Berlin", Postcode: "10115", Aliases: []string{"BER", "Spree-Athen"}})
+	value, exists := mm.GetBySecondaryKey("alias", "BER")
+	fmt.Printf("value: %v, exists: %v\n", value.Name, exists)
+
+	// Output:
+	// value: Berlin, exists: true
+}
+
+func TestIndexedMultiKeyMap_Add(t *testing.T) {
+	mm, err := NewIndexed[string, indexedCity]()
+	require.NoError(t, err)
+
+	berlin := indexedCity{Name: "Berlin", Postcode: "10115", Aliases: []string{"BER", "Spree-Athen"}, AreaCodes: [2]int{30}, ZIP: 10115}
+	require.NoError(t, mm.Add(berlin))
+	require.NoError(t, mm.Add(indexedCity{Name: "Hamburg", Aliases: []string{"HH"}}))
+
+	assert.Equal(t, map[string]map[string]string{
+		"postcode": {"10115": "Berlin"},
+		"alias":    {"BER": "Berlin", "Spree-Athen": "Berlin", "HH": "Hamburg"},
+		"areacode": {"30": "Berlin"},
+		"zip":      {"10115": "Berlin"},
+	}, mm.GetAllKeyGroups())
+	value, exists := mm.GetBySecondaryKey("areacode", "30")
+	assert.True(t, exists)
+	assert.Equal(t, berlin, value)
+}
+
+func TestIndexedMultiKeyMap_Add_Replace(t *testing.T) {
+	mm, err := NewIndexed[string, indexedCity]()
+	require.NoError(t, err)
+	require.NoError(t, mm.Add(indexedCity{Name: "Berlin", Postcode: "10115", Aliases: []string{"BER"}}))
+	require.NoError(t, mm.Add(indexedCity{Name: "Hamburg"}))
+
+	require.NoError(t, mm.Add(indexedCity{Name: "Berlin", Aliases: []string{"B"}, Population: 3_500_000}))
+	assert.False(t, mm.HasSecondaryKey("postcode", "10115"))
+	assert.False(t, mm.HasSecondaryKey("alias", "BER"))
+	value, exists := mm.GetBySecondaryKey("alias", "B")
+	assert.True(t, exists)
+	assert.Equal(t, 3_500_000, value.Population)
+
+	page, _, err := mm.Page("", 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Berlin", page[0].PrimaryKey)
+}
+
+func TestIndexedMultiKeyMap_Add_Conflict(t *testing.T) {
+	mm, err := NewIndexed[string, *indexedCity]()
+	require.NoError(t, err)
+	require.NoError(t, mm.Add(&indexedCity{Name: "Berlin", Aliases: []string{"BER"}}))
+
+	err = mm.Add(&indexedCity{Name: "Bern", Postcode: "3000", Aliases: []string{"BER"}})
+	require.ErrorIs(t, err, ErrSecondaryKeyConflict)
+	var conflictErr *ConflictError[string]
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, ConflictError[string]{Group: "alias", Key: "BER", PrimaryKey: "Bern", ExistingPrimaryKey: "Berlin"}, *conflictErr)
+	assert.False(t, mm.HasPrimaryKey("Bern"))
+	assert.False(t, mm.HasSecondaryKey("postcode", "3000"))
+
+	assert.ErrorIs(t, mm.Add(nil), ErrNilValue)
+	assert.Equal(t, 1, mm.Size())
+}
+
+func TestIndexedMultiKeyMap_Add_NilInterfacePrimaryKey(t *testing.T) {
+	type device struct {
+		ID     any    `mkm:"primary"`
+		Serial string `mkm:"group=serial"`
+	}
+	mm, err := NewIndexed[any, device]()
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, mm.Add(device{Serial: "S1"}), ErrNilPrimaryKey)
+	assert.True(t, mm.Empty())
+	assert.False(t, mm.HasSecondaryKey("serial", "S1"))
+
+	require.NoError(t, mm.Add(device{ID: 1, Serial: "S1"}))
+	value, found := mm.GetBySecondaryKey("serial", "S1")
+	assert.True(t, found)
+	assert.Equal(t, 1, value.ID)
+}
+
+func TestIndexedMultiKeyMap_Add_UncomparablePrimaryKey(t *testing.T) {
+	type device struct {
+		ID     any    `mkm:"primary"`
+		Serial string `mkm:"group=serial"`
+	}
+	mm, err := NewIndexed[any, *device]()
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, mm.Add(&device{ID: []byte("id"), Serial: "S1"}), ErrUncomparablePrimaryKey)
+	assert.ErrorIs(t, mm.Add(&device{ID: struct{ Parts []string }{}, Serial: "S1"}), ErrUncomparablePrimaryKey)
+	assert.True(t, mm.Empty())
+	assert.False(t, mm.HasSecondaryKey("serial", "S1"))
+
+	require.NoError(t, mm.Add(&device{ID: [2]byte{'i', 'd'}, Serial: "S1"}))
+	assert.True(t, mm.HasPrimaryKey([2]byte{'i', 'd'}))
+}
+
+func TestIndexedMultiKeyMap_Remove(t *testing.T) {
+	mm, err := NewIndexed[string, indexedCity](WithInterning())
+	require.NoError(t, err)
+	require.NoError(t, mm.Add(indexedCity{Name: "Berlin", Aliases: []string{"BER"}}))
+
+	mm.Remove("Berlin")
+	assert.True(t, mm.Empty())
+	assert.Empty(t, mm.GetAllKeyGroups())
+}
+
+func TestIndexedMultiKeyMap_String(t *testing.T) {
+	mm, err := NewIndexed[string, indexedCity](WithInsertionOrder())
+	require.NoError(t, err)
+	require.NoError(t, mm.Add(indexedCity{Name: "Hamburg"}))
+	require.NoError(t, mm.Add(indexedCity{Name: "Berlin"}))
+	assert.Equal(t, "IndexedMultiKeyMap: [{Hamburg {Hamburg  [] [0 0] 0 0}} {Berlin {Berlin  [] [0 0] 0 0}}]", mm.String())
+}
+
+func TestIndexedMultiKeyMap_KeyedContainer(t *testing.T) {
+	mm, err := NewIndexed[string, indexedCity]()
+	require.NoError(t, err)
+	require.NoError(t, mm.Add(indexedCity{Name: "Berlin"}))
+
+	var keyed container.KeyedContainer[string, indexedCity] = mm
+	assert.Equal(t, []string{"Berlin"}, keyed.Keys())
+	assert.True(t, keyed.Has("Berlin"))
+	assert.Equal(t, map[string]indexedCity{"Berlin": {Name: "Berlin"}}, container.Collect(keyed))
+}
+
+func TestNewIndexed_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		newMap  func() error
+		message string
+	}{
+		{
+			name:    "no struct",
+			newMap:  func() error { _, err := NewIndexed[string, string](); return err },
+			message: "string is not a struct or a pointer to a struct",
+		},
+		{
+			name: "no primary",
+			newMap: func() error {
+				_, err := NewIndexed[string, struct {
+					Name string `mkm:"group=name"`
+				}]()
+				return err
+			},
+			message: "is tagged with primary",
+		},
+		{
+			name: "two primaries",
+			newMap: func() error {
+				_, err := NewIndexed[string, struct {
+					A string `mkm:"primary"`
+					B string `mkm:"primary"`
+				}]()
+				return err
+			},
+			message: "fields A and B are both tagged with primary",
+		},
+		{
+			name: "primary type",
+			newMap: func() error {
+				_, err := NewIndexed[string, struct {
+					ID int `mkm:"primary"`
+				}]()
+				return err
+			},
+			message: "primary field ID has type int, but the primary key type is string",
+		},
+		{
+			name: "unexported",
+			newMap: func() error {
+				_, err := NewIndexed[string, struct {
+					ID   string `mkm:"primary"`
+					name string `mkm:"group=name"`
+				}]()
+				return err
+			},
+			message: "field name is not exported",
+		},
+		{
+			name: "unknown tag",
+			newMap: func() error {
+				_, err := NewIndexed[string, struct {
+					ID   string `mkm:"primary"`
+					Name string `mkm:"secondary"`
+				}]()
+				return err
+			},
+			message: `field Name has tag "secondary", expected primary or group=<name>`,
+		},
+		{
+			name: "unknown option",
+			newMap: func() error {
+				_, err := NewIndexed[string, struct {
+					ID   string `mkm:"primary"`
+					Name string `mkm:"group=name,unique"`
+				}]()
+				return err
+			},
+			message: `field Name has unknown option "unique"`,
+		},
+		{
+			name: "duplicate group",
+			newMap: func() error {
+				_, err := NewIndexed[string, struct {
+					ID   string `mkm:"primary"`
+					Name string `mkm:"group=name"`
+					Slug string `mkm:"group=name"`
+				}]()
+				return err
+			},
+			message: "fields Name and Slug are both tagged with group name",
+		},
+		{
+			name: "slice without multi",
+			newMap: func() error {
+				_, err := NewIndexed[string, struct {
+					ID      string   `mkm:"primary"`
+					Aliases []string `mkm:"group=alias"`
+				}]()
+				return err
+			},
+			message: "field Aliases of type []string is a slice or array, but is not tagged with multi",
+		},
+		{
+			name: "multi without slice",
+			newMap: func() error {
+				_, err := NewIndexed[string, struct {
+					ID   string `mkm:"primary"`
+					Name string `mkm:"group=name,multi"`
+				}]()
+				return err
+			},
+			message: "field Name of type string is tagged with multi, but is not a slice or array",
+		},
+		{
+			name: "unsupported type",
+			newMap: func() error {
+				_, err := NewIndexed[string, struct {
+					ID    string  `mkm:"primary"`
+					Ratio float64 `mkm:"group=ratio"`
+				}]()
+				return err
+			},
+			message: "field Ratio has unsupported type float64, expected a string or an integer",
+		},
+		{
+			name: "unsupported element type",
+			newMap: func() error {
+				_, err := NewIndexed[string, struct {
+					ID   string              `mkm:"primary"`
+					Tags []map[string]string `mkm:"group=tag,multi"`
+				}]()
+				return err
+			},
+			message: "field Tags has unsupported type []map[string]string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.newMap()
+			require.ErrorIs(t, err, ErrInvalidIndexTag)
+			assert.Contains(t, err.Error(), tt.message)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package multikeymap
+
+import (
+	"iter"
+
+	"github.com/aeimer/go-multikeymap/container"
+)
+
+// Interface is implemented by MultiKeyMap, ConcurrentMultiKeyMap, HashedMultiKeyMap, OrderedMultiKeyMap
+// and SlabMultiKeyMap.
+// It allows code to be generic over these implementations, e.g. for decorators or mocks.
+// MoveToFront and MoveToBack are not part of it, as an OrderedMultiKeyMap is always in the order of its keys.
+type Interface[K any, V any] interface {
+	container.KeyedContainer[K, V]
+	Put(primaryKey K, value V)
+	PutSecondaryKeys(primaryKey K, group string, keys ...string)
+	RemoveSecondaryKeys(primaryKey K, group string, keys ...string)
+	HasPrimaryKey(primaryKey K) bool
+	HasSecondaryKey(group string, key string) bool
+	GetAllKeyGroups() map[string]map[string]K
+	Remove(primaryKey K)
+	RekeyPrimary(oldPrimaryKey K, newPrimaryKey K) error
+	GetBySecondaryKey(group string, key string) (V, bool)
+	Groups() []string
+	GroupSize(group string) int
+	KeysInGroup(group string) iter.Seq[string]
+	SecondaryKeysOf(primaryKey K) map[string][]string
+	GroupBy(group string) map[string][]V
+	Query(q Query) iter.Seq2[K, V]
+	Page(cursor Cursor, limit int) ([]Entry[K, V], Cursor, error)
+	GroupPage(group string, cursor Cursor, limit int) ([]GroupEntry[K, V], Cursor, error)
+}
+
+var (
+	_ Interface[string, int] = (*MultiKeyMap[string, int])(nil)
+	_ Interface[string, int] = (*ConcurrentMultiKeyMap[string, int])(nil)
+	_ Interface[[]byte, int] = (*HashedMultiKeyMap[[]byte, int])(nil)
+	_ Interface[string, int] = (*OrderedMultiKeyMap[string, int])(nil)
+	_ Interface[string, int] = (*SlabMultiKeyMap[string, int])(nil)
+)
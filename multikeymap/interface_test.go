@@ -0,0 +1,43 @@
+package multikeymap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterface(t *testing.T) {
+	implementations := map[string]func() Interface[string, int]{
+		"MultiKeyMap":           func() Interface[string, int] { return New[string, int]() },
+		"ConcurrentMultiKeyMap": func() Interface[string, int] { return NewConcurrent[string, int]() },
+		"OrderedMultiKeyMap":    func() Interface[string, int] { return NewOrdered[string, int]() },
+		"SlabMultiKeyMap":       func() Interface[string, int] { return NewSlab[string, int]() },
+	}
+	for name, newMap := range implementations {
+		t.Run(name, func(t *testing.T) {
+			mm := newMap()
+			mm.Put("key1", 1)
+			mm.PutSecondaryKeys("key1", "group1", "secKey1")
+			require.NoError(t, mm.RekeyPrimary("key1", "key2"))
+
+			value, exists := mm.GetBySecondaryKey("group1", "secKey1")
+			assert.True(t, exists)
+			assert.Equal(t, 1, value)
+			for primaryKey, value := range mm.Query(Eq("group1", "secKey1")) {
+				assert.Equal(t, "key2", primaryKey)
+				assert.Equal(t, 1, value)
+			}
+			assert.Equal(t, []string{"group1"}, mm.Groups())
+			assert.Equal(t, map[string][]string{"group1": {"secKey1"}}, mm.SecondaryKeysOf("key2"))
+			assert.Equal(t, map[string][]int{"secKey1": {1}}, mm.GroupBy("group1"))
+			entries, _, err := mm.Page("", 10)
+			require.NoError(t, err)
+			assert.Equal(t, []Entry[string, int]{{"key2", 1}}, entries)
+
+			mm.RemoveSecondaryKeys("key2", "group1", "secKey1")
+			assert.False(t, mm.HasSecondaryKey("group1", "secKey1"))
+			assert.True(t, mm.HasPrimaryKey("key2"))
+		})
+	}
+}
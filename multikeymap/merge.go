@@ -0,0 +1,108 @@
+package multikeymap
+
+import (
+	"fmt"
+)
+
+// MergeStrategy decides how Merge handles conflicts between the destination and the source map.
+type MergeStrategy int
+
+const (
+	// KeepExisting keeps the value or secondary key of the destination map.
+	KeepExisting MergeStrategy = iota
+	// Overwrite replaces the value or secondary key of the destination map with the one of the source map.
+	Overwrite
+	// ErrorOnConflict aborts the merge with ErrMergeConflict.
+	// If a secondary key points to different primary keys, the error also wraps a *ConflictError.
+	ErrorOnConflict
+)
+
+// MergePolicy configures how Merge handles conflicts.
+type MergePolicy[K comparable, V any] struct {
+	// Strategy handles primary keys which exist in both maps
+	// and secondary keys which point to different primary keys in both maps.
+	Strategy MergeStrategy
+	// Resolve, if set, replaces Strategy for primary keys which exist in both maps.
+	// It returns the merged value or an error which aborts the merge.
+	// Secondary key conflicts are still handled by Strategy.
+	Resolve func(primaryKey K, existing V, incoming V) (V, error)
+}
+
+// Merge copies all primary and secondary keys of src into dst.
+// Conflicts are handled according to the policy.
+// If an error is returned, dst is left unchanged.
+func Merge[K comparable, V any](dst, src *MultiKeyMap[K, V], policy MergePolicy[K, V]) error {
+	values, err := mergeValues(dst, src, policy)
+	if err != nil {
+		return err
+	}
+	secondaryKeys, err := mergeSecondaryKeys(dst, src, policy.Strategy)
+	if err != nil {
+		return err
+	}
+
+	for primaryKey, value := range values {
+		dst.Put(primaryKey, value)
+	}
+	for group, keys := range secondaryKeys {
+		for key, primaryKey := range keys {
+			dst.PutSecondaryKeys(primaryKey, group, key)
+		}
+	}
+	return nil
+}
+
+// mergeValues returns the values of src which have to be put into dst.
+func mergeValues[K comparable, V any](dst, src *MultiKeyMap[K, V], policy MergePolicy[K, V]) (map[K]V, error) {
+	values := make(map[K]V, len(src.primary))
+	for primaryKey, incoming := range src.primary {
+		existing, exists := dst.primary[primaryKey]
+		if !exists {
+			values[primaryKey] = incoming
+			continue
+		}
+
+		if policy.Resolve != nil {
+			resolved, err := policy.Resolve(primaryKey, existing, incoming)
+			if err != nil {
+				return nil, err
+			}
+			values[primaryKey] = resolved
+			continue
+		}
+
+		switch policy.Strategy {
+		case KeepExisting:
+		case Overwrite:
+			values[primaryKey] = incoming
+		case ErrorOnConflict:
+			return nil, fmt.Errorf("%w: primary key %v exists in both maps", ErrMergeConflict, primaryKey)
+		}
+	}
+	return values, nil
+}
+
+// mergeSecondaryKeys returns the secondary keys of src which have to be put into dst.
+func mergeSecondaryKeys[K comparable, V any](dst, src *MultiKeyMap[K, V], strategy MergeStrategy) (map[string]map[string]K, error) {
+	secondaryKeys := make(map[string]map[string]K, len(src.secondary))
+	for group, keys := range src.secondary {
+		for key, primaryKey := range keys {
+			if owner, exists := dst.secondary[group][key]; exists && owner != primaryKey {
+				switch strategy {
+				case KeepExisting:
+					continue
+				case Overwrite:
+				case ErrorOnConflict:
+					return nil, fmt.Errorf("%w: %w", ErrMergeConflict, &ConflictError[K]{
+						Group: group, Key: key, PrimaryKey: primaryKey, ExistingPrimaryKey: owner,
+					})
+				}
+			}
+			if secondaryKeys[group] == nil {
+				secondaryKeys[group] = make(map[string]K)
+			}
+			secondaryKeys[group][key] = primaryKey
+		}
+	}
+	return secondaryKeys, nil
+}
@@ -0,0 +1,103 @@
+package multikeymap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMergeMaps() (*MultiKeyMap[string, int], *MultiKeyMap[string, int]) {
+	dst := New[string, int]()
+	dst.Put("key1", 1)
+	dst.Put("key2", 2)
+	dst.PutSecondaryKeys("key1", "group1", "secKey1")
+
+	src := New[string, int]()
+	src.Put("key2", 20)
+	src.Put("key3", 3)
+	src.PutSecondaryKeys("key3", "group1", "secKey1", "secKey3")
+	return dst, src
+}
+
+func TestMerge_KeepExisting(t *testing.T) {
+	dst, src := newMergeMaps()
+	err := Merge(dst, src, MergePolicy[string, int]{Strategy: KeepExisting})
+	require.NoError(t, err)
+
+	value, _ := dst.Get("key2")
+	assert.Equal(t, 2, value)
+	value, _ = dst.Get("key3")
+	assert.Equal(t, 3, value)
+	value, _ = dst.GetBySecondaryKey("group1", "secKey1")
+	assert.Equal(t, 1, value)
+	value, _ = dst.GetBySecondaryKey("group1", "secKey3")
+	assert.Equal(t, 3, value)
+}
+
+func TestMerge_Overwrite(t *testing.T) {
+	dst, src := newMergeMaps()
+	err := Merge(dst, src, MergePolicy[string, int]{Strategy: Overwrite})
+	require.NoError(t, err)
+
+	value, _ := dst.Get("key2")
+	assert.Equal(t, 20, value)
+	value, _ = dst.GetBySecondaryKey("group1", "secKey1")
+	assert.Equal(t, 3, value)
+
+	// The moved secondary key must not be removed together with its former primary key.
+	dst.Remove("key1")
+	assert.True(t, dst.HasSecondaryKey("group1", "secKey1"))
+}
+
+func TestMerge_ErrorOnConflict(t *testing.T) {
+	dst, src := newMergeMaps()
+	err := Merge(dst, src, MergePolicy[string, int]{Strategy: ErrorOnConflict})
+	require.ErrorIs(t, err, ErrMergeConflict)
+
+	// The destination must be unchanged.
+	assert.Equal(t, 2, dst.Size())
+	assert.False(t, dst.HasPrimaryKey("key3"))
+
+	src.Remove("key2")
+	err = Merge(dst, src, MergePolicy[string, int]{Strategy: ErrorOnConflict})
+	require.ErrorIs(t, err, ErrMergeConflict)
+	require.ErrorIs(t, err, ErrSecondaryKeyConflict)
+	assert.False(t, dst.HasPrimaryKey("key3"))
+
+	var conflictErr *ConflictError[string]
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, "group1", conflictErr.Group)
+	assert.Equal(t, "secKey1", conflictErr.Key)
+	assert.Equal(t, "key3", conflictErr.PrimaryKey)
+	assert.Equal(t, "key1", conflictErr.ExistingPrimaryKey)
+}
+
+func TestMerge_Resolve(t *testing.T) {
+	dst, src := newMergeMaps()
+	err := Merge(dst, src, MergePolicy[string, int]{
+		Strategy: KeepExisting,
+		Resolve: func(_ string, existing int, incoming int) (int, error) {
+			return existing + incoming, nil
+		},
+	})
+	require.NoError(t, err)
+
+	value, _ := dst.Get("key2")
+	assert.Equal(t, 22, value)
+	value, _ = dst.GetBySecondaryKey("group1", "secKey1")
+	assert.Equal(t, 1, value)
+}
+
+func TestMerge_ResolveError(t *testing.T) {
+	dst, src := newMergeMaps()
+	resolveErr := errors.New("cannot resolve")
+	err := Merge(dst, src, MergePolicy[string, int]{
+		Resolve: func(_ string, _ int, _ int) (int, error) {
+			return 0, resolveErr
+		},
+	})
+	require.ErrorIs(t, err, resolveErr)
+	assert.False(t, dst.HasPrimaryKey("key3"))
+}
@@ -2,6 +2,10 @@ package multikeymap
 
 import (
 	"fmt"
+	"iter"
+
+	"github.com/aeimer/go-multikeymap/internal/keyorder"
+	"github.com/aeimer/go-multikeymap/internal/sortedset"
 )
 
 // MultiKeyMap is a generic in-memory map with a primary key and multiple secondary keys.
@@ -9,35 +13,93 @@ import (
 type MultiKeyMap[K comparable, V any] struct {
 	primary     map[K]V
 	secondary   map[string]map[string]K // Group -> SecondaryKey -> PrimaryKey
-	secondaryTo map[K]map[string]string // PrimaryKey -> Group -> SecondaryKey
+	secondaryTo reverseIndex[K]         // PrimaryKey -> Group -> SecondaryKeys
+	// order is the order in which the primary keys were put, which Page follows.
+	// With WithInsertionOrder, iteration follows it as well and MoveToFront and MoveToBack change it.
+	order          *keyorder.List[K]
+	insertionOrder bool
+	// sortedGroups are the secondary keys of the groups in lexical order for GroupPage.
+	// A group is only added by its first GroupPage and then kept up to date.
+	sortedGroups map[string]*sortedset.Set[string]
+	// filters are the bloom filters of the groups if WithBloomFilter is used, nil otherwise.
+	filters *groupFilters
 }
 
 // New creates a new MultiKeyMap instance.
-func New[K comparable, V any]() *MultiKeyMap[K, V] {
-	return &MultiKeyMap[K, V]{
-		primary:     make(map[K]V),
-		secondary:   make(map[string]map[string]K),
-		secondaryTo: make(map[K]map[string]string),
+func New[K comparable, V any](opts ...Option) *MultiKeyMap[K, V] {
+	o := newOptions(opts)
+	m := &MultiKeyMap[K, V]{
+		primary:        make(map[K]V),
+		secondary:      make(map[string]map[string]K),
+		secondaryTo:    newReverseIndex[K](o),
+		order:          keyorder.New[K](),
+		insertionOrder: o.insertionOrder,
+	}
+	if o.bloomFilter {
+		m.filters = newGroupFilters()
 	}
+	return m
 }
 
 // Put inserts a value with a primary key.
 func (m *MultiKeyMap[K, V]) Put(primaryKey K, value V) {
 	m.primary[primaryKey] = value
+	m.order.PushBack(primaryKey)
 }
 
 // PutSecondaryKeys adds secondary keys under a group for a primary key.
+// A secondary key which already points to another primary key is moved to the given primary key.
 func (m *MultiKeyMap[K, V]) PutSecondaryKeys(primaryKey K, group string, keys ...string) {
+	for _, key := range keys {
+		if owner, exists := m.secondary[group][key]; exists && owner != primaryKey {
+			m.unlinkSecondaryKey(owner, group, key)
+		}
+		m.linkSecondaryKey(primaryKey, group, key)
+	}
+}
+
+// RemoveSecondaryKeys removes secondary keys from a group if they point to the given primary key.
+// Secondary keys which do not exist or point to another primary key are not changed.
+func (m *MultiKeyMap[K, V]) RemoveSecondaryKeys(primaryKey K, group string, keys ...string) {
+	for _, key := range keys {
+		if owner, exists := m.secondary[group][key]; exists && owner == primaryKey {
+			m.removeSecondaryKey(group, key)
+		}
+	}
+}
+
+// linkSecondaryKey points a secondary key to a primary key and adds it to the reverse index.
+func (m *MultiKeyMap[K, V]) linkSecondaryKey(primaryKey K, group string, key string) {
+	group, key = m.secondaryTo.add(primaryKey, group, key)
 	if m.secondary[group] == nil {
 		m.secondary[group] = make(map[string]K)
 	}
-	if m.secondaryTo[primaryKey] == nil {
-		m.secondaryTo[primaryKey] = make(map[string]string)
+	_, exists := m.secondary[group][key]
+	m.secondary[group][key] = primaryKey
+	if exists {
+		return
 	}
-	for _, key := range keys {
-		m.secondary[group][key] = primaryKey
-		m.secondaryTo[primaryKey][group] = key
+	if m.filters != nil {
+		m.filterAdd(group, key)
+	}
+	if sorted, exists := m.sortedGroups[group]; exists {
+		sorted.Add(key)
+	}
+}
+
+// removeSecondaryKey removes a single secondary key from a group.
+func (m *MultiKeyMap[K, V]) removeSecondaryKey(group string, key string) {
+	primaryKey, exists := m.secondary[group][key]
+	if !exists {
+		return
 	}
+	m.deleteSecondaryKey(group, key)
+	m.unlinkSecondaryKey(primaryKey, group, key)
+}
+
+// unlinkSecondaryKey removes a secondary key from the reverse index of a primary key.
+func (m *MultiKeyMap[K, V]) unlinkSecondaryKey(primaryKey K, group string, key string) {
+	m.secondaryTo.remove(primaryKey, group, key)
 }
 
 // HasPrimaryKey checks if a primary key exists.
@@ -48,6 +110,9 @@ func (m *MultiKeyMap[K, V]) HasPrimaryKey(primaryKey K) bool {
 
 // HasSecondaryKey checks if a secondary key exists in a specific group.
 func (m *MultiKeyMap[K, V]) HasSecondaryKey(group string, key string) bool {
+	if !m.mayContain(group, key) {
+		return false
+	}
 	if groupKeys, exists := m.secondary[group]; exists {
 		_, exists := groupKeys[key]
 		return exists
@@ -71,15 +136,56 @@ func (m *MultiKeyMap[K, V]) GetAllKeyGroups() map[string]map[string]K {
 // Remove removes a primary key and its associated secondary keys.
 func (m *MultiKeyMap[K, V]) Remove(primaryKey K) {
 	delete(m.primary, primaryKey)
-	if groups, exists := m.secondaryTo[primaryKey]; exists {
-		for group, key := range groups {
-			delete(m.secondary[group], key)
-			if len(m.secondary[group]) == 0 {
-				delete(m.secondary, group)
-			}
-		}
-		delete(m.secondaryTo, primaryKey)
+	m.order.Remove(primaryKey)
+	m.removeSecondaryKeysOf(primaryKey)
+}
+
+// removeSecondaryKeysOf removes all secondary keys of a primary key.
+func (m *MultiKeyMap[K, V]) removeSecondaryKeysOf(primaryKey K) {
+	for group, key := range m.secondaryTo.keys(primaryKey) {
+		m.deleteSecondaryKey(group, key)
 	}
+	m.secondaryTo.removeAll(primaryKey)
+}
+
+// deleteSecondaryKey deletes a secondary key from its group, and the group if it becomes empty.
+func (m *MultiKeyMap[K, V]) deleteSecondaryKey(group string, key string) {
+	delete(m.secondary[group], key)
+	if len(m.secondary[group]) == 0 {
+		delete(m.secondary, group)
+		delete(m.sortedGroups, group)
+	} else if sorted, exists := m.sortedGroups[group]; exists {
+		sorted.Delete(key)
+	}
+	if m.filters != nil {
+		m.filterRemove(group)
+	}
+}
+
+// RekeyPrimary moves the value and all secondary keys of oldPrimaryKey to newPrimaryKey.
+// The entry keeps its position in the insertion order.
+// It returns ErrPrimaryKeyNotFound if oldPrimaryKey does not exist
+// and ErrPrimaryKeyExists if newPrimaryKey already exists.
+func (m *MultiKeyMap[K, V]) RekeyPrimary(oldPrimaryKey K, newPrimaryKey K) error {
+	value, exists := m.primary[oldPrimaryKey]
+	if !exists {
+		return ErrPrimaryKeyNotFound
+	}
+	if oldPrimaryKey == newPrimaryKey {
+		return nil
+	}
+	if _, exists := m.primary[newPrimaryKey]; exists {
+		return ErrPrimaryKeyExists
+	}
+
+	m.primary[newPrimaryKey] = value
+	delete(m.primary, oldPrimaryKey)
+	m.order.Rekey(oldPrimaryKey, newPrimaryKey)
+	for group, key := range m.secondaryTo.keys(oldPrimaryKey) {
+		m.secondary[group][key] = newPrimaryKey
+	}
+	m.secondaryTo.move(oldPrimaryKey, newPrimaryKey)
+	return nil
 }
 
 // Get returns a value by primary key.
@@ -90,6 +196,9 @@ func (m *MultiKeyMap[K, V]) Get(primaryKey K) (V, bool) {
 
 // GetBySecondaryKey returns a primary key by secondary key and group.
 func (m *MultiKeyMap[K, V]) GetBySecondaryKey(group string, key string) (V, bool) {
+	if !m.mayContain(group, key) {
+		return *new(V), false
+	}
 	if groupKeys, exists := m.secondary[group]; exists {
 		primaryKey, exists := groupKeys[key]
 		if exists {
@@ -105,6 +214,66 @@ func (m *MultiKeyMap[K, V]) Size() int {
 	return len(m.primary)
 }
 
+// Keys returns a slice of all primary keys in the map.
+func (m *MultiKeyMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.primary))
+	for primaryKey := range m.All() {
+		keys = append(keys, primaryKey)
+	}
+	return keys
+}
+
+// MoveToFront moves an entry to the front of the insertion order.
+// It returns ErrNoInsertionOrder if the map was not created with WithInsertionOrder
+// and ErrPrimaryKeyNotFound if the primary key does not exist.
+func (m *MultiKeyMap[K, V]) MoveToFront(primaryKey K) error {
+	if !m.insertionOrder {
+		return ErrNoInsertionOrder
+	}
+	if !m.order.MoveToFront(primaryKey) {
+		return ErrPrimaryKeyNotFound
+	}
+	return nil
+}
+
+// MoveToBack moves an entry to the back of the insertion order.
+// It returns ErrNoInsertionOrder if the map was not created with WithInsertionOrder
+// and ErrPrimaryKeyNotFound if the primary key does not exist.
+func (m *MultiKeyMap[K, V]) MoveToBack(primaryKey K) error {
+	if !m.insertionOrder {
+		return ErrNoInsertionOrder
+	}
+	if !m.order.MoveToBack(primaryKey) {
+		return ErrPrimaryKeyNotFound
+	}
+	return nil
+}
+
+// Has checks if a primary key exists. It is the same as HasPrimaryKey.
+func (m *MultiKeyMap[K, V]) Has(primaryKey K) bool {
+	return m.HasPrimaryKey(primaryKey)
+}
+
+// All returns an iterator over all primary keys and their values.
+// With WithInsertionOrder, the entries are returned in insertion order.
+func (m *MultiKeyMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.insertionOrder {
+			for primaryKey := range m.order.All() {
+				if !yield(primaryKey, m.primary[primaryKey]) {
+					return
+				}
+			}
+			return
+		}
+		for primaryKey, value := range m.primary {
+			if !yield(primaryKey, value) {
+				return
+			}
+		}
+	}
+}
+
 // Empty checks if the map is empty.
 func (m *MultiKeyMap[K, V]) Empty() bool {
 	return len(m.primary) == 0
@@ -113,7 +282,7 @@ func (m *MultiKeyMap[K, V]) Empty() bool {
 // Values returns a slice of all values in the map.
 func (m *MultiKeyMap[K, V]) Values() []V {
 	values := make([]V, 0, len(m.primary))
-	for _, value := range m.primary {
+	for _, value := range m.All() {
 		values = append(values, value)
 	}
 	return values
@@ -123,10 +292,23 @@ func (m *MultiKeyMap[K, V]) Values() []V {
 func (m *MultiKeyMap[K, V]) Clear() {
 	m.primary = make(map[K]V)
 	m.secondary = make(map[string]map[string]K)
-	m.secondaryTo = make(map[K]map[string]string)
+	m.secondaryTo.clear()
+	m.sortedGroups = nil
+	m.order.Clear()
+	if m.filters != nil {
+		m.filters = newGroupFilters()
+	}
 }
 
 // String returns a string representation of the map.
+// With WithInsertionOrder, the entries are listed in insertion order.
 func (m *MultiKeyMap[K, V]) String() string {
+	if m.insertionOrder {
+		entries := make([]Entry[K, V], 0, len(m.primary))
+		for primaryKey, value := range m.All() {
+			entries = append(entries, Entry[K, V]{PrimaryKey: primaryKey, Value: value})
+		}
+		return fmt.Sprintf("MultiKeyMap: %v", entries)
+	}
 	return fmt.Sprintf("MultiKeyMap: %v", m.primary)
 }
@@ -2,11 +2,13 @@ package multikeymap
 
 import (
 	"fmt"
+	"runtime"
 	"strconv"
 	"testing"
 
 	"github.com/aeimer/go-multikeymap/container"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func ExampleNew() {
@@ -24,6 +26,29 @@ func ExampleNew() {
 	// [Secondary group1 key2] value: 1, exists: true
 }
 
+func ExampleWithInterning() {
+	mm := New[string, int](WithInterning())
+	mm.Put("Berlin", 3_500_000)
+	mm.PutSecondaryKeys("Berlin", "postcode", "10115", "10117")
+	value, exists := mm.GetBySecondaryKey("postcode", "10117")
+	fmt.Printf("value: %v, exists: %v\n", value, exists)
+
+	// Output:
+	// value: 3500000, exists: true
+}
+
+func ExampleWithInsertionOrder() {
+	mm := New[string, int](WithInsertionOrder())
+	mm.Put("c", 3)
+	mm.Put("a", 1)
+	mm.Put("b", 2)
+	_ = mm.MoveToFront("b")
+	fmt.Println(mm.Keys())
+
+	// Output:
+	// [b c a]
+}
+
 func TestMultiKeyMap_ImplementsContainerInterface(t *testing.T) {
 	instance := New[int, int]()
 	if _, ok := any(instance).(container.Container[int]); !ok {
@@ -31,6 +56,21 @@ func TestMultiKeyMap_ImplementsContainerInterface(t *testing.T) {
 	}
 }
 
+func TestMultiKeyMap_KeyedContainer(t *testing.T) {
+	mm := New[string, int]()
+	mm.Put("key1", 1)
+	mm.Put("key2", 2)
+
+	var keyed container.KeyedContainer[string, int] = mm
+	assert.ElementsMatch(t, []string{"key1", "key2"}, keyed.Keys())
+	assert.True(t, keyed.Has("key1"))
+	assert.False(t, keyed.Has("key3"))
+	value, exists := keyed.Get("key2")
+	assert.True(t, exists)
+	assert.Equal(t, 2, value)
+	assert.Equal(t, map[string]int{"key1": 1, "key2": 2}, container.Collect(keyed))
+}
+
 func TestMultiKeyMap_SetAndGet(t *testing.T) {
 	mm := New[string, int]()
 	mm.Put("key1", 1)
@@ -80,6 +120,174 @@ func TestMultiKeyMap_Remove(t *testing.T) {
 	}
 }
 
+func TestMultiKeyMap_Remove_MultipleSecondaryKeys(t *testing.T) {
+	mm := New[string, int]()
+	mm.Put("key1", 1)
+	mm.PutSecondaryKeys("key1", "group1", "secKey1", "secKey2")
+	mm.Remove("key1")
+	assert.False(t, mm.HasSecondaryKey("group1", "secKey1"))
+	assert.False(t, mm.HasSecondaryKey("group1", "secKey2"))
+	assert.Empty(t, mm.GetAllKeyGroups())
+}
+
+func TestMultiKeyMap_PutSecondaryKeys_MovesKey(t *testing.T) {
+	mm := New[string, int]()
+	mm.Put("key1", 1)
+	mm.Put("key2", 2)
+	mm.PutSecondaryKeys("key1", "group1", "secKey1")
+	mm.PutSecondaryKeys("key2", "group1", "secKey1")
+	mm.Remove("key1")
+
+	value, exists := mm.GetBySecondaryKey("group1", "secKey1")
+	assert.True(t, exists)
+	assert.Equal(t, 2, value)
+}
+
+func TestMultiKeyMap_RemoveSecondaryKeys(t *testing.T) {
+	mm := New[string, int]()
+	mm.Put("key1", 1)
+	mm.Put("key2", 2)
+	mm.PutSecondaryKeys("key1", "group1", "secKey1", "secKey2")
+	mm.PutSecondaryKeys("key2", "group1", "secKey3")
+
+	mm.RemoveSecondaryKeys("key1", "group1", "secKey1", "secKey3", "missing")
+	assert.False(t, mm.HasSecondaryKey("group1", "secKey1"))
+	assert.True(t, mm.HasSecondaryKey("group1", "secKey2"))
+	assert.True(t, mm.HasSecondaryKey("group1", "secKey3"))
+	assert.Equal(t, map[string][]string{"group1": {"secKey2"}}, mm.SecondaryKeysOf("key1"))
+
+	mm.RemoveSecondaryKeys("key1", "group1", "secKey2")
+	assert.Empty(t, mm.SecondaryKeysOf("key1"))
+	assert.Equal(t, map[string]map[string]string{"group1": {"secKey3": "key2"}}, mm.GetAllKeyGroups())
+}
+
+func TestMultiKeyMap_RekeyPrimary(t *testing.T) {
+	mm := New[string, int]()
+	mm.Put("key1", 1)
+	mm.Put("key2", 2)
+	mm.PutSecondaryKeys("key1", "group1", "secKey1", "secKey2")
+	mm.PutSecondaryKeys("key1", "group2", "secKey3")
+
+	require.NoError(t, mm.RekeyPrimary("key1", "key3"))
+	assert.False(t, mm.HasPrimaryKey("key1"))
+	value, exists := mm.Get("key3")
+	assert.True(t, exists)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, map[string]map[string]string{
+		"group1": {"secKey1": "key3", "secKey2": "key3"},
+		"group2": {"secKey3": "key3"},
+	}, mm.GetAllKeyGroups())
+
+	// The secondary keys must be removed together with the new primary key.
+	mm.Remove("key3")
+	assert.Empty(t, mm.GetAllKeyGroups())
+	assert.Equal(t, 1, mm.Size())
+}
+
+func TestMultiKeyMap_RekeyPrimary_ToKeyWithSecondaryKeys(t *testing.T) {
+	mm := New[string, int]()
+	mm.Put("key1", 1)
+	mm.PutSecondaryKeys("key1", "group1", "secKey1")
+	mm.PutSecondaryKeys("key2", "group1", "secKey2")
+
+	// key2 has no value, but secondary keys which must be kept.
+	require.NoError(t, mm.RekeyPrimary("key1", "key2"))
+	mm.Remove("key2")
+	assert.Empty(t, mm.GetAllKeyGroups())
+}
+
+func TestMultiKeyMap_RekeyPrimary_Errors(t *testing.T) {
+	mm := New[string, int]()
+	mm.Put("key1", 1)
+	mm.Put("key2", 2)
+	mm.PutSecondaryKeys("key1", "group1", "secKey1")
+
+	require.ErrorIs(t, mm.RekeyPrimary("key3", "key4"), ErrPrimaryKeyNotFound)
+	require.ErrorIs(t, mm.RekeyPrimary("key1", "key2"), ErrPrimaryKeyExists)
+	require.NoError(t, mm.RekeyPrimary("key1", "key1"))
+
+	// Failed rekeys must leave the map unchanged.
+	value, _ := mm.GetBySecondaryKey("group1", "secKey1")
+	assert.Equal(t, 1, value)
+	value, _ = mm.Get("key2")
+	assert.Equal(t, 2, value)
+}
+
+func TestMultiKeyMap_WithInterning(t *testing.T) {
+	build := func(opts ...Option) *MultiKeyMap[string, int] {
+		mm := New[string, int](opts...)
+		mm.Put("key1", 1)
+		mm.Put("key2", 2)
+		mm.Put("key3", 3)
+		mm.PutSecondaryKeys("key1", "group1", "sk1", "sk2", "sk2")
+		mm.PutSecondaryKeys("key1", "group2", "sk1")
+		mm.PutSecondaryKeys("key2", "group1", "sk2", "sk3")
+		mm.PutSecondaryKeys("key4", "group2", "sk4")
+		require.NoError(t, mm.RekeyPrimary("key2", "key4"))
+		mm.Remove("key3")
+		return mm
+	}
+	expected := build()
+	mm := build(WithInterning())
+
+	assert.Equal(t, expected.GetAllKeyGroups(), mm.GetAllKeyGroups())
+	assert.Equal(t, map[string]map[string]string{
+		"group1": {"sk1": "key1", "sk2": "key4", "sk3": "key4"},
+		"group2": {"sk1": "key1", "sk4": "key4"},
+	}, mm.GetAllKeyGroups())
+	value, exists := mm.GetBySecondaryKey("group1", "sk2")
+	assert.True(t, exists)
+	assert.Equal(t, 2, value)
+
+	mm.Remove("key4")
+	assert.Equal(t, map[string]map[string]string{
+		"group1": {"sk1": "key1"},
+		"group2": {"sk1": "key1"},
+	}, mm.GetAllKeyGroups())
+	mm.Clear()
+	assert.Empty(t, mm.GetAllKeyGroups())
+	mm.PutSecondaryKeys("key1", "group3", "sk1")
+	assert.True(t, mm.HasSecondaryKey("group3", "sk1"))
+}
+
+func TestMultiKeyMap_WithInsertionOrder(t *testing.T) {
+	mm := New[string, int](WithInsertionOrder())
+	mm.Put("key3", 3)
+	mm.Put("key1", 1)
+	mm.Put("key2", 2)
+	mm.Put("key3", 30)
+	assert.Equal(t, []string{"key3", "key1", "key2"}, mm.Keys())
+	assert.Equal(t, []int{30, 1, 2}, mm.Values())
+	assert.Equal(t, "MultiKeyMap: [{key3 30} {key1 1} {key2 2}]", mm.String())
+
+	require.NoError(t, mm.MoveToBack("key3"))
+	require.NoError(t, mm.MoveToFront("key2"))
+	assert.Equal(t, []string{"key2", "key1", "key3"}, mm.Keys())
+	require.ErrorIs(t, mm.MoveToFront("key4"), ErrPrimaryKeyNotFound)
+	require.ErrorIs(t, mm.MoveToBack("key4"), ErrPrimaryKeyNotFound)
+
+	require.NoError(t, mm.RekeyPrimary("key1", "key4"))
+	mm.Remove("key2")
+	mm.Put("key5", 5)
+	assert.Equal(t, []string{"key4", "key3", "key5"}, mm.Keys())
+
+	for primaryKey := range mm.All() {
+		mm.Remove(primaryKey)
+	}
+	assert.True(t, mm.Empty())
+	mm.Put("key6", 6)
+	mm.Clear()
+	mm.Put("key7", 7)
+	assert.Equal(t, []string{"key7"}, mm.Keys())
+}
+
+func TestMultiKeyMap_MoveWithoutInsertionOrder(t *testing.T) {
+	mm := New[string, int]()
+	mm.Put("key1", 1)
+	require.ErrorIs(t, mm.MoveToFront("key1"), ErrNoInsertionOrder)
+	require.ErrorIs(t, mm.MoveToBack("key1"), ErrNoInsertionOrder)
+}
+
 func TestMultiKeyMap_GetAllKeyGroups(t *testing.T) {
 	mm := New[string, int]()
 	mm.Put("key1", 1)
@@ -209,3 +417,94 @@ func BenchmarkMultiKeyMapRemove(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkMultiKeyMapPutRemoveInsertionOrder(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size_%d", v.size), func(b *testing.B) {
+			m := New[string, int](WithInsertionOrder())
+			b.ResetTimer()
+			for range b.N {
+				for n := range v.size {
+					m.Put(strconv.Itoa(n), n)
+				}
+				for n := range v.size {
+					m.Remove(strconv.Itoa(n))
+				}
+			}
+		})
+	}
+}
+
+var benchmarkModes = []struct {
+	name string
+	opts []Option
+	// slab runs the mode with a SlabMultiKeyMap instead of a MultiKeyMap.
+	slab bool
+}{
+	{name: "default"},
+	{name: "interned", opts: []Option{WithInterning()}},
+	{name: "slab", slab: true},
+}
+
+// newBenchmarkMap creates the map of a benchmark mode.
+func newBenchmarkMap(slab bool, opts []Option) Interface[string, int] {
+	if slab {
+		return NewSlab[string, int](opts...)
+	}
+	return New[string, int](opts...)
+}
+
+// putBenchmarkSecondaryKeys puts size primary keys with two secondary keys in each of two groups.
+func putBenchmarkSecondaryKeys(m Interface[string, int], size int) {
+	for n := range size {
+		primaryKey := strconv.Itoa(n)
+		m.Put(primaryKey, n)
+		m.PutSecondaryKeys(primaryKey, "postcode", "p"+primaryKey, "q"+primaryKey)
+		m.PutSecondaryKeys(primaryKey, "alias", "a"+primaryKey, "b"+primaryKey)
+	}
+}
+
+// reportHeapPerEntry reports the heap memory retained by a map with size entries as B/entry.
+func reportHeapPerEntry(b *testing.B, size int, slab bool, opts []Option) {
+	b.Helper()
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	m := newBenchmarkMap(slab, opts)
+	putBenchmarkSecondaryKeys(m, size)
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+	b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/float64(size), "B/entry")
+	runtime.KeepAlive(m)
+}
+
+func BenchmarkMultiKeyMapPutSecondaryKeys(b *testing.B) {
+	for _, mode := range benchmarkModes {
+		for _, v := range benchmarkSizes {
+			b.Run(fmt.Sprintf("%s/size_%d", mode.name, v.size), func(b *testing.B) {
+				for range b.N {
+					putBenchmarkSecondaryKeys(newBenchmarkMap(mode.slab, mode.opts), v.size)
+				}
+				b.StopTimer()
+				reportHeapPerEntry(b, v.size, mode.slab, mode.opts)
+			})
+		}
+	}
+}
+
+func BenchmarkMultiKeyMapGetBySecondaryKey(b *testing.B) {
+	for _, mode := range benchmarkModes {
+		for _, v := range benchmarkSizes {
+			b.Run(fmt.Sprintf("%s/size_%d", mode.name, v.size), func(b *testing.B) {
+				m := newBenchmarkMap(mode.slab, mode.opts)
+				putBenchmarkSecondaryKeys(m, v.size)
+				b.ResetTimer()
+				for range b.N {
+					for n := range v.size {
+						m.GetBySecondaryKey("postcode", "p"+strconv.Itoa(n))
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package multikeymap
+
+// Option configures a MultiKeyMap when it is created.
+type Option func(*options)
+
+type options struct {
+	interning      bool
+	insertionOrder bool
+	bloomFilter    bool
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithInterning stores every group name and secondary key only once.
+// Group names are replaced by small integer IDs internally and secondary keys are interned with unique.Handle,
+// which reduces the memory usage of maps with many secondary keys.
+// The trade-off is speed: putting secondary keys takes about three times as long,
+// and GetBySecondaryKey is about 1.3 to 1.7 times slower for large maps,
+// as the interned keys are not stored next to each other in memory.
+// The IDs of group names are only released by Clear, so it should not be used with an unbounded number of groups.
+func WithInterning() Option {
+	return func(o *options) {
+		o.interning = true
+	}
+}
+
+// WithInsertionOrder keeps the primary keys in the order in which they were first put, like a linked hash map.
+// Keys, Values, All and String return the entries in this order
+// and MoveToFront and MoveToBack change the position of an entry, also for Page.
+// Putting, removing and moving an entry stays amortized O(1).
+func WithInsertionOrder() Option {
+	return func(o *options) {
+		o.insertionOrder = true
+	}
+}
+
+// WithBloomFilter keeps a bloom filter for every group of secondary keys,
+// which lets GetBySecondaryKey and HasSecondaryKey reject most missing keys without probing the group.
+// The filters are updated by putting and removing secondary keys
+// and are rebuilt when they are full or many of their keys were removed.
+// They need a few bytes per secondary key and make putting secondary keys slower.
+func WithBloomFilter() Option {
+	return func(o *options) {
+		o.bloomFilter = true
+	}
+}
@@ -0,0 +1,225 @@
+package multikeymap
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+
+	"github.com/aeimer/go-multikeymap/internal/sortedset"
+)
+
+// OrderedMultiKeyMap is the same as MultiKeyMap, but it iterates in the order of the primary keys.
+// Keys, Values, All and String are ordered as well, so their output is deterministic.
+// Lookups are O(1) like MultiKeyMap, putting and removing a primary key takes O(log n) additionally.
+// The map must not be modified while iterating over it.
+// OrderedMultiKeyMap is not safe for concurrent use.
+type OrderedMultiKeyMap[K cmp.Ordered, V any] struct {
+	keys sortedset.Set[K]
+	base MultiKeyMap[K, V]
+}
+
+// NewOrdered creates a new OrderedMultiKeyMap instance.
+func NewOrdered[K cmp.Ordered, V any](opts ...Option) *OrderedMultiKeyMap[K, V] {
+	return &OrderedMultiKeyMap[K, V]{
+		base: *New[K, V](opts...),
+	}
+}
+
+// Put inserts a value with a primary key.
+func (m *OrderedMultiKeyMap[K, V]) Put(primaryKey K, value V) {
+	m.base.Put(primaryKey, value)
+	m.keys.Add(primaryKey)
+}
+
+// PutSecondaryKeys adds secondary keys under a group for a primary key.
+// A secondary key which already points to another primary key is moved to the given primary key.
+func (m *OrderedMultiKeyMap[K, V]) PutSecondaryKeys(primaryKey K, group string, keys ...string) {
+	m.base.PutSecondaryKeys(primaryKey, group, keys...)
+}
+
+// RemoveSecondaryKeys removes secondary keys from a group if they point to the given primary key.
+// Secondary keys which do not exist or point to another primary key are not changed.
+func (m *OrderedMultiKeyMap[K, V]) RemoveSecondaryKeys(primaryKey K, group string, keys ...string) {
+	m.base.RemoveSecondaryKeys(primaryKey, group, keys...)
+}
+
+// HasPrimaryKey checks if a primary key exists.
+func (m *OrderedMultiKeyMap[K, V]) HasPrimaryKey(primaryKey K) bool {
+	return m.base.HasPrimaryKey(primaryKey)
+}
+
+// HasSecondaryKey checks if a secondary key exists in a specific group.
+func (m *OrderedMultiKeyMap[K, V]) HasSecondaryKey(group string, key string) bool {
+	return m.base.HasSecondaryKey(group, key)
+}
+
+// GetAllKeyGroups returns all key groups and their secondary keys.
+func (m *OrderedMultiKeyMap[K, V]) GetAllKeyGroups() map[string]map[string]K {
+	return m.base.GetAllKeyGroups()
+}
+
+// Remove removes a primary key and its associated secondary keys.
+func (m *OrderedMultiKeyMap[K, V]) Remove(primaryKey K) {
+	m.base.Remove(primaryKey)
+	m.keys.Delete(primaryKey)
+}
+
+// RekeyPrimary moves the value and all secondary keys of oldPrimaryKey to newPrimaryKey.
+// The entry keeps its position in the insertion order.
+// It returns ErrPrimaryKeyNotFound if oldPrimaryKey does not exist
+// and ErrPrimaryKeyExists if newPrimaryKey already exists.
+func (m *OrderedMultiKeyMap[K, V]) RekeyPrimary(oldPrimaryKey K, newPrimaryKey K) error {
+	if err := m.base.RekeyPrimary(oldPrimaryKey, newPrimaryKey); err != nil {
+		return err
+	}
+	m.keys.Delete(oldPrimaryKey)
+	m.keys.Add(newPrimaryKey)
+	return nil
+}
+
+// Get returns a value by primary key.
+func (m *OrderedMultiKeyMap[K, V]) Get(primaryKey K) (V, bool) {
+	return m.base.Get(primaryKey)
+}
+
+// GetBySecondaryKey returns a primary key by secondary key and group.
+func (m *OrderedMultiKeyMap[K, V]) GetBySecondaryKey(group string, key string) (V, bool) {
+	return m.base.GetBySecondaryKey(group, key)
+}
+
+// Groups returns the names of all groups which have at least one secondary key, in lexical order.
+func (m *OrderedMultiKeyMap[K, V]) Groups() []string {
+	return m.base.Groups()
+}
+
+// GroupSize returns the number of secondary keys in a group.
+func (m *OrderedMultiKeyMap[K, V]) GroupSize(group string) int {
+	return m.base.GroupSize(group)
+}
+
+// KeysInGroup returns an iterator over the secondary keys of a group in no particular order.
+// The map must not be modified during the iteration.
+func (m *OrderedMultiKeyMap[K, V]) KeysInGroup(group string) iter.Seq[string] {
+	return m.base.KeysInGroup(group)
+}
+
+// SecondaryKeysOf returns the secondary keys of a primary key by group.
+// The keys of each group are in lexical order. Without secondary keys, the map is empty.
+func (m *OrderedMultiKeyMap[K, V]) SecondaryKeysOf(primaryKey K) map[string][]string {
+	return m.base.SecondaryKeysOf(primaryKey)
+}
+
+// GroupBy returns the values of all entries with a secondary key in the group, keyed by that secondary key.
+// Secondary keys whose primary key has no value are left out.
+// A secondary key points to a single primary key, so each slice has exactly one element.
+func (m *OrderedMultiKeyMap[K, V]) GroupBy(group string) map[string][]V {
+	return m.base.GroupBy(group)
+}
+
+// Query returns an iterator over all entries matching the query.
+func (m *OrderedMultiKeyMap[K, V]) Query(q Query) iter.Seq2[K, V] {
+	return m.base.Query(q)
+}
+
+// Page returns up to limit entries following the cursor in insertion order, and the cursor for the next page.
+func (m *OrderedMultiKeyMap[K, V]) Page(cursor Cursor, limit int) ([]Entry[K, V], Cursor, error) {
+	return m.base.Page(cursor, limit)
+}
+
+// GroupPage returns up to limit secondary keys of the group following the cursor together with their entries,
+// ordered by the secondary key, and the cursor for the next page.
+func (m *OrderedMultiKeyMap[K, V]) GroupPage(group string, cursor Cursor, limit int) ([]GroupEntry[K, V], Cursor, error) {
+	return m.base.GroupPage(group, cursor, limit)
+}
+
+// Ascend returns an iterator over all entries in ascending order of the primary keys.
+func (m *OrderedMultiKeyMap[K, V]) Ascend() iter.Seq2[K, V] {
+	return m.entries(m.keys.Ascend())
+}
+
+// Descend returns an iterator over all entries in descending order of the primary keys.
+func (m *OrderedMultiKeyMap[K, V]) Descend() iter.Seq2[K, V] {
+	return m.entries(m.keys.Descend())
+}
+
+// AscendRange returns an iterator over the entries whose primary key is in the range [greaterOrEqual, lessThan)
+// in ascending order of the primary keys.
+func (m *OrderedMultiKeyMap[K, V]) AscendRange(greaterOrEqual K, lessThan K) iter.Seq2[K, V] {
+	return m.entries(m.keys.AscendRange(greaterOrEqual, lessThan))
+}
+
+// entries returns an iterator over the entries of the primary keys.
+func (m *OrderedMultiKeyMap[K, V]) entries(keys iter.Seq[K]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for primaryKey := range keys {
+			if !yield(primaryKey, m.base.primary[primaryKey]) {
+				return
+			}
+		}
+	}
+}
+
+// First returns the entry with the smallest primary key. It returns false if the map is empty.
+func (m *OrderedMultiKeyMap[K, V]) First() (K, V, bool) {
+	primaryKey, exists := m.keys.Min()
+	return primaryKey, m.base.primary[primaryKey], exists
+}
+
+// Last returns the entry with the largest primary key. It returns false if the map is empty.
+func (m *OrderedMultiKeyMap[K, V]) Last() (K, V, bool) {
+	primaryKey, exists := m.keys.Max()
+	return primaryKey, m.base.primary[primaryKey], exists
+}
+
+// Keys returns a slice of all primary keys in the map in ascending order.
+func (m *OrderedMultiKeyMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.keys.Len())
+	for primaryKey := range m.keys.Ascend() {
+		keys = append(keys, primaryKey)
+	}
+	return keys
+}
+
+// Has checks if a primary key exists. It is the same as HasPrimaryKey.
+func (m *OrderedMultiKeyMap[K, V]) Has(primaryKey K) bool {
+	return m.base.Has(primaryKey)
+}
+
+// All returns an iterator over all primary keys and their values in ascending order. It is the same as Ascend.
+func (m *OrderedMultiKeyMap[K, V]) All() iter.Seq2[K, V] {
+	return m.Ascend()
+}
+
+// Size returns the number of elements in the map.
+func (m *OrderedMultiKeyMap[K, V]) Size() int {
+	return m.base.Size()
+}
+
+// Empty checks if the map is empty.
+func (m *OrderedMultiKeyMap[K, V]) Empty() bool {
+	return m.base.Empty()
+}
+
+// Values returns a slice of all values in the map in ascending order of the primary keys.
+func (m *OrderedMultiKeyMap[K, V]) Values() []V {
+	values := make([]V, 0, m.keys.Len())
+	for _, value := range m.Ascend() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Clear removes all elements from the map.
+func (m *OrderedMultiKeyMap[K, V]) Clear() {
+	m.base.Clear()
+	m.keys.Clear()
+}
+
+// String returns a string representation of the map in ascending order of the primary keys.
+func (m *OrderedMultiKeyMap[K, V]) String() string {
+	entries := make([]Entry[K, V], 0, m.keys.Len())
+	for primaryKey, value := range m.Ascend() {
+		entries = append(entries, Entry[K, V]{PrimaryKey: primaryKey, Value: value})
+	}
+	return fmt.Sprintf("OrderedMultiKeyMap: %v", entries)
+}
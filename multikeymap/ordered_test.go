@@ -0,0 +1,116 @@
+package multikeymap
+
+import (
+	"fmt"
+	"iter"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleNewOrdered() {
+	mm := NewOrdered[string, int]()
+	mm.Put("Hamburg", 1_800_000)
+	mm.Put("Berlin", 3_500_000)
+	mm.Put("Munich", 1_500_000)
+	for city, population := range mm.Ascend() {
+		fmt.Printf("%s: %d\n", city, population)
+	}
+
+	// Output:
+	// Berlin: 3500000
+	// Hamburg: 1800000
+	// Munich: 1500000
+}
+
+// orderedKeys collects the keys of an iterator in their order.
+func orderedKeys[K any, V any](seq iter.Seq2[K, V]) []K {
+	var keys []K
+	for key := range seq {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestOrderedMultiKeyMap_Ascend(t *testing.T) {
+	mm := NewOrdered[int, string]()
+	for _, key := range []int{5, 1, 4, 2, 3} {
+		mm.Put(key, fmt.Sprint("value", key))
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, orderedKeys(mm.Ascend()))
+	assert.Equal(t, []int{5, 4, 3, 2, 1}, orderedKeys(mm.Descend()))
+	assert.Equal(t, []int{2, 3}, orderedKeys(mm.AscendRange(2, 4)))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, orderedKeys(mm.All()))
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, mm.Keys())
+	assert.Equal(t, []string{"value1", "value2", "value3", "value4", "value5"}, mm.Values())
+	assert.Equal(t, "OrderedMultiKeyMap: [{1 value1} {2 value2} {3 value3} {4 value4} {5 value5}]", mm.String())
+
+	key, value, exists := mm.First()
+	assert.True(t, exists)
+	assert.Equal(t, 1, key)
+	assert.Equal(t, "value1", value)
+	key, value, exists = mm.Last()
+	assert.True(t, exists)
+	assert.Equal(t, 5, key)
+	assert.Equal(t, "value5", value)
+}
+
+func TestOrderedMultiKeyMap_Modify(t *testing.T) {
+	mm := NewOrdered[int, string]()
+	mm.Put(1, "a")
+	mm.Put(2, "b")
+	mm.Put(2, "c")
+	mm.PutSecondaryKeys(3, "group1", "key1")
+	assert.Equal(t, []int{1, 2}, mm.Keys())
+
+	require.NoError(t, mm.RekeyPrimary(1, 3))
+	assert.Equal(t, []int{2, 3}, mm.Keys())
+	require.ErrorIs(t, mm.RekeyPrimary(1, 4), ErrPrimaryKeyNotFound)
+	assert.Equal(t, []int{2, 3}, mm.Keys())
+
+	mm.Remove(2)
+	mm.Remove(5)
+	assert.Equal(t, []int{3}, mm.Keys())
+	value, exists := mm.GetBySecondaryKey("group1", "key1")
+	assert.True(t, exists)
+	assert.Equal(t, "a", value)
+
+	mm.Clear()
+	assert.Empty(t, mm.Keys())
+	_, _, exists = mm.First()
+	assert.False(t, exists)
+	_, _, exists = mm.Last()
+	assert.False(t, exists)
+}
+
+func BenchmarkOrderedMultiKeyMapPut(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size_%d", v.size), func(b *testing.B) {
+			m := NewOrdered[int, int]()
+			b.ResetTimer()
+			for range b.N {
+				for n := range v.size {
+					m.Put(n, n)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOrderedMultiKeyMapAscend(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size_%d", v.size), func(b *testing.B) {
+			m := NewOrdered[int, int]()
+			for n := range v.size {
+				m.Put(n, n)
+			}
+			b.ResetTimer()
+			for range b.N {
+				for range m.Ascend() {
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package multikeymap
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+
+	"github.com/aeimer/go-multikeymap/internal/sortedset"
+)
+
+// Cursor marks the position after the last entry of a page.
+// The empty cursor starts at the first entry, and an empty cursor is returned after the last page.
+// A cursor is opaque, but it can be stored and transferred as a string.
+type Cursor string
+
+const (
+	primaryCursorPrefix = "p"
+	groupCursorPrefix   = "g"
+)
+
+// GroupEntry is a single secondary key of a group together with the entry it points to.
+type GroupEntry[K any, V any] struct {
+	Key        string
+	PrimaryKey K
+	Value      V
+}
+
+// Page returns up to limit entries following the cursor in insertion order, and the cursor for the next page.
+// An entry which is removed and put again or moved to the back is returned again on a later page,
+// an entry moved to the front is not returned by the following pages.
+func (m *MultiKeyMap[K, V]) Page(cursor Cursor, limit int) ([]Entry[K, V], Cursor, error) {
+	if limit <= 0 {
+		return nil, "", ErrInvalidLimit
+	}
+	after, err := decodePrimaryCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries := make([]Entry[K, V], 0, limit)
+	var last uint64
+	more := false
+	for key, seq := range m.order.After(after) {
+		if len(entries) == limit {
+			more = true
+			break
+		}
+		entries = append(entries, Entry[K, V]{PrimaryKey: key, Value: m.primary[key]})
+		last = seq
+	}
+
+	if !more {
+		return entries, "", nil
+	}
+	return entries, encodePrimaryCursor(last), nil
+}
+
+// GroupPage returns up to limit secondary keys of a group following the cursor, in lexical order,
+// and the cursor for the next page.
+// The first call for a group sorts its keys in O(n log n). The sorted keys are then kept up to date,
+// so following pages take O(log n + limit).
+func (m *MultiKeyMap[K, V]) GroupPage(group string, cursor Cursor, limit int) ([]GroupEntry[K, V], Cursor, error) {
+	return m.groupPage(group, cursor, limit, m.Get)
+}
+
+// groupPage is GroupPage with the values looked up by get. Secondary keys for which get returns false are skipped.
+func (m *MultiKeyMap[K, V]) groupPage(group string, cursor Cursor, limit int, get func(K) (V, bool)) ([]GroupEntry[K, V], Cursor, error) {
+	if limit <= 0 {
+		return nil, "", ErrInvalidLimit
+	}
+	after, started, err := decodeGroupCursor(cursor, group)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sorted := m.sortedGroup(group)
+	keys := sorted.Ascend()
+	if started {
+		keys = sorted.AscendGreater(after)
+	}
+	entries := make([]GroupEntry[K, V], 0, min(limit, len(m.secondary[group])))
+	for key := range keys {
+		primaryKey := m.secondary[group][key]
+		value, exists := get(primaryKey)
+		if !exists {
+			continue
+		}
+		if len(entries) == limit {
+			return entries, encodeGroupCursor(group, entries[len(entries)-1].Key), nil
+		}
+		entries = append(entries, GroupEntry[K, V]{Key: key, PrimaryKey: primaryKey, Value: value})
+	}
+	return entries, "", nil
+}
+
+// sortedGroup returns the sorted secondary keys of a group and sorts them if the group has none yet.
+func (m *MultiKeyMap[K, V]) sortedGroup(group string) *sortedset.Set[string] {
+	if sorted, exists := m.sortedGroups[group]; exists {
+		return sorted
+	}
+	sorted := &sortedset.Set[string]{}
+	if len(m.secondary[group]) == 0 {
+		return sorted
+	}
+	for key := range m.secondary[group] {
+		sorted.Add(key)
+	}
+	if m.sortedGroups == nil {
+		m.sortedGroups = make(map[string]*sortedset.Set[string])
+	}
+	m.sortedGroups[group] = sorted
+	return sorted
+}
+
+func encodePrimaryCursor(seq uint64) Cursor {
+	return Cursor(base64.RawURLEncoding.EncodeToString(binary.AppendUvarint([]byte(primaryCursorPrefix), seq)))
+}
+
+func decodePrimaryCursor(cursor Cursor) (uint64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(string(cursor))
+	if err != nil || !strings.HasPrefix(string(raw), primaryCursorPrefix) {
+		return 0, ErrInvalidCursor
+	}
+	seq, n := binary.Uvarint(raw[len(primaryCursorPrefix):])
+	if n <= 0 || len(raw) != len(primaryCursorPrefix)+n {
+		return 0, ErrInvalidCursor
+	}
+	return seq, nil
+}
+
+func encodeGroupCursor(group string, key string) Cursor {
+	raw := binary.AppendUvarint([]byte(groupCursorPrefix), uint64(len(group)))
+	raw = append(raw, group...)
+	raw = append(raw, key...)
+	return Cursor(base64.RawURLEncoding.EncodeToString(raw))
+}
+
+// decodeGroupCursor returns the last returned key and false for the empty cursor.
+func decodeGroupCursor(cursor Cursor, group string) (string, bool, error) {
+	if cursor == "" {
+		return "", false, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(string(cursor))
+	if err != nil || !strings.HasPrefix(string(raw), groupCursorPrefix) {
+		return "", false, ErrInvalidCursor
+	}
+	raw = raw[len(groupCursorPrefix):]
+	groupLen, n := binary.Uvarint(raw)
+	if n <= 0 || uint64(len(raw)-n) < groupLen {
+		return "", false, ErrInvalidCursor
+	}
+	raw = raw[n:]
+	if string(raw[:groupLen]) != group {
+		return "", false, ErrInvalidCursor
+	}
+	return string(raw[groupLen:]), true, nil
+}
@@ -0,0 +1,257 @@
+package multikeymap
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleMultiKeyMap_Page() {
+	mm := New[string, int]()
+	for n := range 5 {
+		mm.Put("key"+strconv.Itoa(n), n)
+	}
+
+	var cursor Cursor
+	for {
+		entries, next, err := mm.Page(cursor, 2)
+		if err != nil {
+			panic(err)
+		}
+		fmt.Println(entries)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	// Output:
+	// [{key0 0} {key1 1}]
+	// [{key2 2} {key3 3}]
+	// [{key4 4}]
+}
+
+func collectPages(t *testing.T, mm *MultiKeyMap[int, int], limit int) []int {
+	t.Helper()
+	var keys []int
+	var cursor Cursor
+	for {
+		entries, next, err := mm.Page(cursor, limit)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			keys = append(keys, entry.PrimaryKey)
+		}
+		if next == "" {
+			return keys
+		}
+		cursor = next
+	}
+}
+
+func TestMultiKeyMap_Page(t *testing.T) {
+	mm := New[int, int]()
+	for n := range 10 {
+		mm.Put(n, n)
+	}
+	// Putting an existing key keeps its position.
+	mm.Put(3, 30)
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, collectPages(t, mm, 3))
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, collectPages(t, mm, 10))
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, collectPages(t, mm, 100))
+}
+
+func TestMultiKeyMap_Page_Empty(t *testing.T) {
+	mm := New[int, int]()
+	entries, next, err := mm.Page("", 10)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.Equal(t, Cursor(""), next)
+}
+
+func TestMultiKeyMap_Page_ModifiedBetweenPages(t *testing.T) {
+	mm := New[int, int]()
+	for n := range 10 {
+		mm.Put(n, n)
+	}
+
+	entries, cursor, err := mm.Page("", 4)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	// Remove the last returned key and most of the others, so the order is compacted.
+	for _, n := range []int{0, 1, 2, 3, 5, 6} {
+		mm.Remove(n)
+	}
+	mm.Put(10, 10)
+	mm.Put(0, 0)
+
+	var keys []int
+	for cursor != "" {
+		entries, cursor, err = mm.Page(cursor, 2)
+		require.NoError(t, err)
+		for _, entry := range entries {
+			keys = append(keys, entry.PrimaryKey)
+		}
+	}
+	assert.Equal(t, []int{4, 7, 8, 9, 10, 0}, keys)
+}
+
+func TestMultiKeyMap_Page_RekeyPrimary(t *testing.T) {
+	mm := New[int, int]()
+	for n := range 4 {
+		mm.Put(n, n)
+	}
+	entries, cursor, err := mm.Page("", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry[int, int]{{0, 0}, {1, 1}}, entries)
+
+	// A rekeyed entry keeps its position.
+	require.NoError(t, mm.RekeyPrimary(1, 10))
+	require.NoError(t, mm.RekeyPrimary(2, 20))
+	entries, _, err = mm.Page(cursor, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry[int, int]{{20, 2}, {3, 3}}, entries)
+	assert.Equal(t, []int{0, 10, 20, 3}, collectPages(t, mm, 3))
+}
+
+func TestMultiKeyMap_Page_Clear(t *testing.T) {
+	mm := New[int, int]()
+	mm.Put(1, 1)
+	mm.Put(2, 2)
+	_, cursor, err := mm.Page("", 1)
+	require.NoError(t, err)
+
+	mm.Clear()
+	mm.Put(3, 3)
+	entries, _, err := mm.Page(cursor, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry[int, int]{{PrimaryKey: 3, Value: 3}}, entries)
+}
+
+func TestMultiKeyMap_Page_InvalidArguments(t *testing.T) {
+	mm := New[int, int]()
+	mm.Put(1, 1)
+	mm.PutSecondaryKeys(1, "group1", "secKey1")
+
+	_, _, err := mm.Page("", 0)
+	require.ErrorIs(t, err, ErrInvalidLimit)
+	_, _, err = mm.Page("not a cursor", 1)
+	require.ErrorIs(t, err, ErrInvalidCursor)
+	_, _, err = mm.Page(encodeGroupCursor("group1", "secKey1"), 1)
+	require.ErrorIs(t, err, ErrInvalidCursor)
+	_, _, err = mm.GroupPage("group1", "", -1)
+	require.ErrorIs(t, err, ErrInvalidLimit)
+	_, _, err = mm.GroupPage("group1", encodePrimaryCursor(1), 1)
+	require.ErrorIs(t, err, ErrInvalidCursor)
+	_, _, err = mm.GroupPage("group2", encodeGroupCursor("group1", "secKey1"), 1)
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestMultiKeyMap_GroupPage(t *testing.T) {
+	mm := New[int, int]()
+	mm.Put(1, 10)
+	mm.Put(2, 20)
+	mm.PutSecondaryKeys(1, "group1", "c", "a")
+	mm.PutSecondaryKeys(2, "group1", "b", "d", "e")
+	mm.PutSecondaryKeys(2, "group2", "f")
+
+	entries, cursor, err := mm.GroupPage("group1", "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []GroupEntry[int, int]{{Key: "a", PrimaryKey: 1, Value: 10}, {Key: "b", PrimaryKey: 2, Value: 20}}, entries)
+
+	// Keys added before the cursor are skipped, keys after it are returned.
+	mm.PutSecondaryKeys(1, "group1", "aa", "cc")
+	entries, cursor, err = mm.GroupPage("group1", cursor, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []GroupEntry[int, int]{{Key: "c", PrimaryKey: 1, Value: 10}, {Key: "cc", PrimaryKey: 1, Value: 10}}, entries)
+
+	entries, cursor, err = mm.GroupPage("group1", cursor, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []GroupEntry[int, int]{{Key: "d", PrimaryKey: 2, Value: 20}, {Key: "e", PrimaryKey: 2, Value: 20}}, entries)
+	assert.Equal(t, Cursor(""), cursor)
+}
+
+func TestPage_DefaultConstructors(t *testing.T) {
+	indexed, err := NewIndexed[string, indexedCity]()
+	require.NoError(t, err)
+	maps := map[string]interface {
+		Put(primaryKey string, value int)
+		Page(cursor Cursor, limit int) ([]Entry[string, int], Cursor, error)
+	}{
+		"MultiKeyMap":           New[string, int](),
+		"ConcurrentMultiKeyMap": NewConcurrent[string, int](),
+		"HashedMultiKeyMap": NewWithHasher[string, int](func(key string) uint64 { return hashBytes([]byte(key)) },
+			func(a, b string) bool { return a == b }),
+		"OrderedMultiKeyMap": NewOrdered[string, int](),
+		"SlabMultiKeyMap":    NewSlab[string, int](),
+	}
+	for name, mm := range maps {
+		t.Run(name, func(t *testing.T) {
+			mm.Put("b", 1)
+			mm.Put("a", 2)
+			mm.Put("c", 3)
+			entries, cursor, err := mm.Page("", 2)
+			require.NoError(t, err)
+			assert.Equal(t, []Entry[string, int]{{"b", 1}, {"a", 2}}, entries)
+			entries, cursor, err = mm.Page(cursor, 2)
+			require.NoError(t, err)
+			assert.Equal(t, []Entry[string, int]{{"c", 3}}, entries)
+			assert.Equal(t, Cursor(""), cursor)
+		})
+	}
+
+	require.NoError(t, indexed.Add(indexedCity{Name: "Bern"}))
+	require.NoError(t, indexed.Add(indexedCity{Name: "Aarau"}))
+	entries, _, err := indexed.Page("", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bern", "Aarau"}, []string{entries[0].PrimaryKey, entries[1].PrimaryKey})
+}
+
+func TestMultiKeyMap_Page_Move(t *testing.T) {
+	mm := New[int, int](WithInsertionOrder())
+	for n := range 6 {
+		mm.Put(n, n)
+	}
+	entries, cursor, err := mm.Page("", 3)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry[int, int]{{0, 0}, {1, 1}, {2, 2}}, entries)
+
+	// An entry moved to the front is not returned by the following pages, an entry moved to the back is.
+	require.NoError(t, mm.MoveToFront(4))
+	require.NoError(t, mm.MoveToBack(1))
+	entries, _, err = mm.Page(cursor, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry[int, int]{{3, 3}, {5, 5}, {1, 1}}, entries)
+	assert.Equal(t, []int{4, 0, 2, 3, 5, 1}, collectPages(t, mm, 4))
+}
+
+func TestMultiKeyMap_GroupPage_RemoveBetweenPages(t *testing.T) {
+	mm := New[int, int]()
+	for n := range 6 {
+		mm.Put(n, n)
+		mm.PutSecondaryKeys(n, "group1", strconv.Itoa(n))
+	}
+	entries, cursor, err := mm.GroupPage("group1", "", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []GroupEntry[int, int]{{"0", 0, 0}, {"1", 1, 1}}, entries)
+
+	mm.Remove(3)
+	mm.PutSecondaryKeys(5, "group1", "55")
+	entries, _, err = mm.GroupPage("group1", cursor, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []GroupEntry[int, int]{{"2", 2, 2}, {"4", 4, 4}, {"5", 5, 5}, {"55", 5, 5}}, entries)
+
+	// Removing all keys of a group drops its sorted keys, they are sorted again if the group comes back.
+	for n := range 6 {
+		mm.Remove(n)
+	}
+	mm.Put(7, 7)
+	mm.PutSecondaryKeys(7, "group1", "7")
+	entries, _, err = mm.GroupPage("group1", "", 10)
+	require.NoError(t, err)
+	assert.Equal(t, []GroupEntry[int, int]{{"7", 7, 7}}, entries)
+}
@@ -0,0 +1,251 @@
+package multikeymap
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Entry is a single value of a MultiKeyMap together with its primary key.
+type Entry[K any, V any] struct {
+	PrimaryKey K
+	Value      V
+}
+
+// Query is a condition over the secondary keys of a MultiKeyMap.
+// It is built with Eq, In, And, Or and Not and evaluated with MultiKeyMap.Query.
+// As a secondary key points to exactly one primary key, Eq matches at most one entry
+// and In matches at most one entry per given key.
+// Filtering entries by an attribute which many of them share, like all users with the country DE,
+// is out of scope, as it would need secondary keys which point to many primary keys.
+type Query struct {
+	op       queryOp
+	group    string
+	keys     []string
+	children []Query
+}
+
+type queryOp int
+
+const (
+	queryIn queryOp = iota
+	queryAnd
+	queryOr
+	queryNot
+)
+
+// Eq matches all entries which have the secondary key in the group.
+func Eq(group string, key string) Query {
+	return In(group, key)
+}
+
+// In matches all entries which have at least one of the secondary keys in the group.
+func In(group string, keys ...string) Query {
+	return Query{op: queryIn, group: group, keys: keys}
+}
+
+// And matches all entries which match every given query.
+// Without queries, it matches all entries.
+func And(queries ...Query) Query {
+	return Query{op: queryAnd, children: queries}
+}
+
+// Or matches all entries which match at least one of the given queries.
+// Without queries, it matches no entry.
+func Or(queries ...Query) Query {
+	return Query{op: queryOr, children: queries}
+}
+
+// Not matches all entries which do not match the given query.
+func Not(query Query) Query {
+	return Query{op: queryNot, children: []Query{query}}
+}
+
+// Query returns an iterator over all entries matching the query.
+// The matching primary keys are determined when the iteration starts,
+// by intersecting the secondary indexes, beginning with the smallest one.
+// Queries which cannot be answered from the secondary indexes, like a sole Not, scan all entries.
+// The map must not be modified during the iteration.
+func (m *MultiKeyMap[K, V]) Query(q Query) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for primaryKey := range m.queryPrimaryKeys(q) {
+			value, exists := m.primary[primaryKey]
+			if exists && !yield(primaryKey, value) {
+				return
+			}
+		}
+	}
+}
+
+// queryEntries returns all entries matching the query.
+func (m *MultiKeyMap[K, V]) queryEntries(q Query) []Entry[K, V] {
+	var entries []Entry[K, V]
+	for primaryKey, value := range m.Query(q) {
+		entries = append(entries, Entry[K, V]{PrimaryKey: primaryKey, Value: value})
+	}
+	return entries
+}
+
+// queryPrimaryKeys returns the set of primary keys matching the query.
+func (m *MultiKeyMap[K, V]) queryPrimaryKeys(q Query) map[K]struct{} {
+	return m.queryKeys(q, maps.Keys(m.primary))
+}
+
+// queryKeys returns the set of primary keys matching the query.
+// The primary keys in all are only scanned if the query cannot be answered from the secondary indexes.
+func (m *MultiKeyMap[K, V]) queryKeys(q Query, all iter.Seq[K]) map[K]struct{} {
+	if candidates, ok := m.queryCandidates(q); ok {
+		return candidates
+	}
+
+	result := make(map[K]struct{})
+	for primaryKey := range all {
+		if m.queryMatches(q, primaryKey) {
+			result[primaryKey] = struct{}{}
+		}
+	}
+	return result
+}
+
+// queryCandidates returns the primary keys matching the query using only the secondary indexes.
+// It returns false if the query cannot be answered without scanning all entries.
+func (m *MultiKeyMap[K, V]) queryCandidates(q Query) (map[K]struct{}, bool) {
+	switch q.op {
+	case queryIn:
+		result := make(map[K]struct{}, len(q.keys))
+		for _, key := range q.keys {
+			if primaryKey, exists := m.secondary[q.group][key]; exists {
+				result[primaryKey] = struct{}{}
+			}
+		}
+		return result, true
+
+	case queryAnd:
+		children := m.sortBySelectivity(q.children)
+		if len(children) == 0 || !m.queryEnumerable(children[0]) {
+			return nil, false
+		}
+		result, _ := m.queryCandidates(children[0])
+		for primaryKey := range result {
+			for _, child := range children[1:] {
+				if !m.queryMatches(child, primaryKey) {
+					delete(result, primaryKey)
+					break
+				}
+			}
+		}
+		return result, true
+
+	case queryOr:
+		result := make(map[K]struct{})
+		for _, child := range q.children {
+			candidates, ok := m.queryCandidates(child)
+			if !ok {
+				return nil, false
+			}
+			for primaryKey := range candidates {
+				result[primaryKey] = struct{}{}
+			}
+		}
+		return result, true
+
+	case queryNot:
+	}
+	return nil, false
+}
+
+// queryMatches checks if a single primary key matches the query.
+func (m *MultiKeyMap[K, V]) queryMatches(q Query, primaryKey K) bool {
+	switch q.op {
+	case queryIn:
+		for _, key := range q.keys {
+			if owner, exists := m.secondary[q.group][key]; exists && owner == primaryKey {
+				return true
+			}
+		}
+		return false
+	case queryAnd:
+		for _, child := range q.children {
+			if !m.queryMatches(child, primaryKey) {
+				return false
+			}
+		}
+		return true
+	case queryOr:
+		for _, child := range q.children {
+			if m.queryMatches(child, primaryKey) {
+				return true
+			}
+		}
+		return false
+	case queryNot:
+		return !m.queryMatches(q.children[0], primaryKey)
+	}
+	return false
+}
+
+// queryEnumerable checks if the query can be answered from the secondary indexes.
+func (m *MultiKeyMap[K, V]) queryEnumerable(q Query) bool {
+	switch q.op {
+	case queryIn:
+		return true
+	case queryAnd:
+		return slices.ContainsFunc(q.children, m.queryEnumerable)
+	case queryOr:
+		for _, child := range q.children {
+			if !m.queryEnumerable(child) {
+				return false
+			}
+		}
+		return true
+	case queryNot:
+	}
+	return false
+}
+
+// queryEstimate returns an upper bound of the number of primary keys matching the query.
+// The number of entries is taken from the order, as the primary map of a SlabMultiKeyMap is empty.
+func (m *MultiKeyMap[K, V]) queryEstimate(q Query) int {
+	switch q.op {
+	case queryIn:
+		count := 0
+		for _, key := range q.keys {
+			if _, exists := m.secondary[q.group][key]; exists {
+				count++
+			}
+		}
+		return count
+	case queryAnd:
+		estimate := m.order.Len()
+		for _, child := range q.children {
+			if m.queryEnumerable(child) {
+				estimate = min(estimate, m.queryEstimate(child))
+			}
+		}
+		return estimate
+	case queryOr:
+		estimate := 0
+		for _, child := range q.children {
+			estimate += m.queryEstimate(child)
+		}
+		return min(estimate, m.order.Len())
+	case queryNot:
+	}
+	return m.order.Len()
+}
+
+// sortBySelectivity returns the queries ordered with the enumerable ones with the fewest matches first.
+func (m *MultiKeyMap[K, V]) sortBySelectivity(queries []Query) []Query {
+	sorted := slices.Clone(queries)
+	slices.SortStableFunc(sorted, func(a, b Query) int {
+		enumerableA, enumerableB := m.queryEnumerable(a), m.queryEnumerable(b)
+		switch {
+		case enumerableA && !enumerableB:
+			return -1
+		case !enumerableA && enumerableB:
+			return 1
+		}
+		return m.queryEstimate(a) - m.queryEstimate(b)
+	})
+	return sorted
+}
@@ -0,0 +1,96 @@
+package multikeymap
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleMultiKeyMap_Query() {
+	mm := newQueryMap()
+	q := And(In("postcode", "10115", "20095", "80331"), Not(Eq("alias", "HH")))
+	for primaryKey := range mm.Query(q) {
+		fmt.Println(primaryKey)
+	}
+
+	// Unordered output:
+	// Berlin
+	// Munich
+}
+
+func newQueryMap() *MultiKeyMap[string, int] {
+	mm := New[string, int]()
+	mm.Put("Berlin", 3_500_000)
+	mm.Put("Hamburg", 1_800_000)
+	mm.Put("Munich", 1_500_000)
+	mm.Put("Cologne", 1_000_000)
+	mm.PutSecondaryKeys("Berlin", "postcode", "10115", "10117")
+	mm.PutSecondaryKeys("Hamburg", "postcode", "20095")
+	mm.PutSecondaryKeys("Munich", "postcode", "80331")
+	mm.PutSecondaryKeys("Berlin", "alias", "BER")
+	mm.PutSecondaryKeys("Hamburg", "alias", "HH")
+	mm.PutSecondaryKeys("Cologne", "alias", "CGN")
+	return mm
+}
+
+func queryKeys(mm *MultiKeyMap[string, int], q Query) []string {
+	return slices.Sorted(maps.Keys(maps.Collect(mm.Query(q))))
+}
+
+func TestMultiKeyMap_Query(t *testing.T) {
+	mm := newQueryMap()
+	tests := []struct {
+		name     string
+		query    Query
+		expected []string
+	}{
+		{name: "eq", query: Eq("postcode", "10117"), expected: []string{"Berlin"}},
+		{name: "eq unknown key", query: Eq("postcode", "99999"), expected: nil},
+		{name: "eq unknown group", query: Eq("state", "BY"), expected: nil},
+		{name: "in", query: In("postcode", "10115", "10117", "20095"), expected: []string{"Berlin", "Hamburg"}},
+		{
+			name:     "and",
+			query:    And(In("postcode", "10115", "20095", "80331"), In("alias", "BER", "HH")),
+			expected: []string{"Berlin", "Hamburg"},
+		},
+		{name: "or", query: Or(Eq("postcode", "80331"), Eq("alias", "CGN")), expected: []string{"Cologne", "Munich"}},
+		{name: "not", query: Not(In("alias", "BER", "HH")), expected: []string{"Cologne", "Munich"}},
+		{
+			name:     "and not",
+			query:    And(In("postcode", "10115", "20095", "80331"), Not(Eq("alias", "HH"))),
+			expected: []string{"Berlin", "Munich"},
+		},
+		{name: "or not", query: Or(Eq("alias", "BER"), Not(In("postcode", "10115", "20095"))), expected: []string{"Berlin", "Cologne", "Munich"}},
+		{name: "empty and", query: And(), expected: []string{"Berlin", "Cologne", "Hamburg", "Munich"}},
+		{name: "empty or", query: Or(), expected: nil},
+		{
+			name:     "nested",
+			query:    And(Or(Eq("alias", "BER"), Eq("alias", "HH")), Not(Or(Eq("postcode", "20095"), Eq("postcode", "80331")))),
+			expected: []string{"Berlin"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, queryKeys(mm, tt.query))
+		})
+	}
+}
+
+func TestMultiKeyMap_Query_StopIteration(t *testing.T) {
+	mm := newQueryMap()
+	count := 0
+	for range mm.Query(In("alias", "BER", "HH")) {
+		count++
+		break
+	}
+	assert.Equal(t, 1, count)
+}
+
+func TestMultiKeyMap_Query_RemovedPrimaryKey(t *testing.T) {
+	mm := newQueryMap()
+	mm.Remove("Berlin")
+	assert.Equal(t, []string{"Hamburg"}, queryKeys(mm, In("alias", "BER", "HH")))
+}
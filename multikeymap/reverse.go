@@ -0,0 +1,236 @@
+package multikeymap
+
+import (
+	"iter"
+	"slices"
+	"unique"
+)
+
+// reverseIndex stores the secondary keys of every primary key, so they can be removed together with it.
+type reverseIndex[K comparable] interface {
+	// add links a secondary key to a primary key and returns the group and the key as stored by the index.
+	add(primaryKey K, group string, key string) (string, string)
+	remove(primaryKey K, group string, key string)
+	has(primaryKey K) bool
+	// keys returns an iterator over the groups and secondary keys of a primary key.
+	keys(primaryKey K) iter.Seq2[string, string]
+	// move links all secondary keys of oldPrimaryKey to newPrimaryKey.
+	move(oldPrimaryKey K, newPrimaryKey K)
+	removeAll(primaryKey K)
+	clear()
+}
+
+func newReverseIndex[K comparable](o options) reverseIndex[K] {
+	if o.interning {
+		return &internedReverseIndex[K]{
+			groupIDs: make(map[string]uint32),
+			refs:     make(map[K]refSet),
+		}
+	}
+	return make(mapReverseIndex[K])
+}
+
+// mapReverseIndex is the default reverseIndex: PrimaryKey -> Group -> SecondaryKeys.
+type mapReverseIndex[K comparable] map[K]map[string]map[string]struct{}
+
+func (idx mapReverseIndex[K]) add(primaryKey K, group string, key string) (string, string) {
+	if idx[primaryKey] == nil {
+		idx[primaryKey] = make(map[string]map[string]struct{})
+	}
+	if idx[primaryKey][group] == nil {
+		idx[primaryKey][group] = make(map[string]struct{})
+	}
+	idx[primaryKey][group][key] = struct{}{}
+	return group, key
+}
+
+func (idx mapReverseIndex[K]) remove(primaryKey K, group string, key string) {
+	delete(idx[primaryKey][group], key)
+	if len(idx[primaryKey][group]) == 0 {
+		delete(idx[primaryKey], group)
+	}
+	if len(idx[primaryKey]) == 0 {
+		delete(idx, primaryKey)
+	}
+}
+
+func (idx mapReverseIndex[K]) has(primaryKey K) bool {
+	_, exists := idx[primaryKey]
+	return exists
+}
+
+func (idx mapReverseIndex[K]) keys(primaryKey K) iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for group, keys := range idx[primaryKey] {
+			for key := range keys {
+				if !yield(group, key) {
+					return
+				}
+			}
+		}
+	}
+}
+
+func (idx mapReverseIndex[K]) move(oldPrimaryKey K, newPrimaryKey K) {
+	groups, exists := idx[oldPrimaryKey]
+	if !exists {
+		return
+	}
+	delete(idx, oldPrimaryKey)
+	if idx[newPrimaryKey] == nil {
+		idx[newPrimaryKey] = groups
+		return
+	}
+	for group, keys := range groups {
+		for key := range keys {
+			idx.add(newPrimaryKey, group, key)
+		}
+	}
+}
+
+func (idx mapReverseIndex[K]) removeAll(primaryKey K) {
+	delete(idx, primaryKey)
+}
+
+func (idx mapReverseIndex[K]) clear() {
+	clear(idx)
+}
+
+// internedReverseIndex is the reverseIndex used by WithInterning.
+// It stores a compact set of references per primary key instead of nested maps.
+type internedReverseIndex[K comparable] struct {
+	groups   []string          // GroupID -> Group
+	groupIDs map[string]uint32 // Group -> GroupID
+	refs     map[K]refSet
+}
+
+type secondaryRef struct {
+	group uint32
+	key   unique.Handle[string]
+}
+
+// refSetIndexSize is the number of references up to which a refSet is only a slice.
+const refSetIndexSize = 8
+
+// refSet is a set of references. Small sets are a slice which is searched linearly,
+// larger sets additionally index the positions of the references, so adding and removing stays O(1).
+type refSet struct {
+	refs  []secondaryRef
+	index map[secondaryRef]int
+}
+
+func (s *refSet) position(ref secondaryRef) int {
+	if s.index == nil {
+		return slices.Index(s.refs, ref)
+	}
+	if i, exists := s.index[ref]; exists {
+		return i
+	}
+	return -1
+}
+
+func (s *refSet) add(ref secondaryRef) {
+	if s.position(ref) >= 0 {
+		return
+	}
+	s.refs = append(s.refs, ref)
+	switch {
+	case s.index != nil:
+		s.index[ref] = len(s.refs) - 1
+	case len(s.refs) > refSetIndexSize:
+		s.index = make(map[secondaryRef]int, len(s.refs))
+		for i, r := range s.refs {
+			s.index[r] = i
+		}
+	}
+}
+
+func (s *refSet) remove(ref secondaryRef) {
+	i := s.position(ref)
+	if i < 0 {
+		return
+	}
+	last := len(s.refs) - 1
+	s.refs[i] = s.refs[last]
+	s.refs[last] = secondaryRef{}
+	s.refs = s.refs[:last]
+	if s.index != nil {
+		delete(s.index, ref)
+		if i < last {
+			s.index[s.refs[i]] = i
+		}
+	}
+}
+
+func (idx *internedReverseIndex[K]) add(primaryKey K, group string, key string) (string, string) {
+	groupID, exists := idx.groupIDs[group]
+	if !exists {
+		groupID = uint32(len(idx.groups))
+		idx.groups = append(idx.groups, group)
+		idx.groupIDs[group] = groupID
+	}
+	ref := secondaryRef{group: groupID, key: unique.Make(key)}
+	refs := idx.refs[primaryKey]
+	refs.add(ref)
+	idx.refs[primaryKey] = refs
+	return idx.groups[groupID], ref.key.Value()
+}
+
+func (idx *internedReverseIndex[K]) remove(primaryKey K, group string, key string) {
+	groupID, exists := idx.groupIDs[group]
+	if !exists {
+		return
+	}
+	refs, exists := idx.refs[primaryKey]
+	if !exists {
+		return
+	}
+	refs.remove(secondaryRef{group: groupID, key: unique.Make(key)})
+	if len(refs.refs) == 0 {
+		delete(idx.refs, primaryKey)
+	} else {
+		idx.refs[primaryKey] = refs
+	}
+}
+
+func (idx *internedReverseIndex[K]) has(primaryKey K) bool {
+	_, exists := idx.refs[primaryKey]
+	return exists
+}
+
+func (idx *internedReverseIndex[K]) keys(primaryKey K) iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for _, ref := range idx.refs[primaryKey].refs {
+			if !yield(idx.groups[ref.group], ref.key.Value()) {
+				return
+			}
+		}
+	}
+}
+
+func (idx *internedReverseIndex[K]) move(oldPrimaryKey K, newPrimaryKey K) {
+	refs, exists := idx.refs[oldPrimaryKey]
+	if !exists {
+		return
+	}
+	delete(idx.refs, oldPrimaryKey)
+	target, exists := idx.refs[newPrimaryKey]
+	if !exists {
+		idx.refs[newPrimaryKey] = refs
+		return
+	}
+	for _, ref := range refs.refs {
+		target.add(ref)
+	}
+	idx.refs[newPrimaryKey] = target
+}
+
+func (idx *internedReverseIndex[K]) removeAll(primaryKey K) {
+	delete(idx.refs, primaryKey)
+}
+
+func (idx *internedReverseIndex[K]) clear() {
+	idx.groups = nil
+	idx.groupIDs = make(map[string]uint32)
+	idx.refs = make(map[K]refSet)
+}
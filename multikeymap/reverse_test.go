@@ -0,0 +1,100 @@
+package multikeymap
+
+import (
+	"maps"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReverseIndex(t *testing.T) {
+	for name, o := range map[string]options{"default": {}, "interned": {interning: true}} {
+		t.Run(name, func(t *testing.T) {
+			idx := newReverseIndex[int](o)
+			collect := func(primaryKey int) map[string]string {
+				return maps.Collect(idx.keys(primaryKey))
+			}
+
+			group, key := idx.add(1, "group1", "key1")
+			assert.Equal(t, "group1", group)
+			assert.Equal(t, "key1", key)
+			idx.add(1, "group1", "key1")
+			idx.add(1, "group2", "key2")
+			idx.add(2, "group1", "key3")
+			assert.True(t, idx.has(1))
+			assert.Equal(t, map[string]string{"group1": "key1", "group2": "key2"}, collect(1))
+
+			idx.remove(1, "group1", "key1")
+			assert.Equal(t, map[string]string{"group2": "key2"}, collect(1))
+			idx.remove(1, "group2", "key2")
+			assert.False(t, idx.has(1))
+			idx.remove(1, "unknown", "key2")
+
+			idx.add(3, "group2", "key4")
+			idx.move(2, 3)
+			assert.False(t, idx.has(2))
+			assert.Equal(t, map[string]string{"group1": "key3", "group2": "key4"}, collect(3))
+			idx.move(2, 4)
+			assert.False(t, idx.has(4))
+
+			idx.removeAll(3)
+			assert.False(t, idx.has(3))
+			idx.add(5, "group1", "key5")
+			idx.clear()
+			assert.False(t, idx.has(5))
+			assert.Empty(t, collect(5))
+		})
+	}
+}
+
+func TestReverseIndex_ManyKeys(t *testing.T) {
+	for name, o := range map[string]options{"default": {}, "interned": {interning: true}} {
+		t.Run(name, func(t *testing.T) {
+			idx := newReverseIndex[int](o)
+			for n := range 20 {
+				idx.add(1, "group1", strconv.Itoa(n))
+				idx.add(1, "group1", strconv.Itoa(n))
+			}
+			assert.Len(t, maps.Collect(idx.keys(1)), 1)
+			for n := range 20 {
+				if n%3 != 0 {
+					idx.remove(1, "group1", strconv.Itoa(n))
+				}
+			}
+			var keys []string
+			for _, key := range idx.keys(1) {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, []string{"0", "3", "6", "9", "12", "15", "18"}, keys)
+
+			idx.add(2, "group1", "0")
+			idx.move(1, 2)
+			keys = nil
+			for _, key := range idx.keys(2) {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, []string{"0", "3", "6", "9", "12", "15", "18"}, keys)
+		})
+	}
+}
+
+func BenchmarkReverseIndexManyKeys(b *testing.B) {
+	for name, o := range map[string]options{"default": {}, "interned": {interning: true}} {
+		keys := make([]string, 10_000)
+		for n := range keys {
+			keys[n] = strconv.Itoa(n)
+		}
+		b.Run(name, func(b *testing.B) {
+			for range b.N {
+				idx := newReverseIndex[int](o)
+				for _, key := range keys {
+					idx.add(1, "group1", key)
+				}
+				for _, key := range keys {
+					idx.remove(1, "group1", key)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,375 @@
+package multikeymap
+
+import (
+	"fmt"
+	"iter"
+	"maps"
+
+	"github.com/aeimer/go-multikeymap/internal/slab"
+)
+
+// SlabMultiKeyMap is the same as MultiKeyMap, but the values are stored in a slab of fixed-size chunks
+// which are addressed by integer handles instead of in a map.
+// The secondary keys point to the handles, so GetBySecondaryKey needs one map lookup less.
+// RekeyPrimary is O(1) because the handle and therefore the secondary keys stay the same.
+// SlabMultiKeyMap is not safe for concurrent use.
+type SlabMultiKeyMap[K comparable, V any] struct {
+	handles map[K]slab.Handle
+	slots   slab.Slab[slabSlot[K, V]]
+	size    int // number of slots with a value
+	// base holds the secondary keys and the insertion order of the handles.
+	// Its primary map stays empty, as the slots already record which handles have a value.
+	base MultiKeyMap[slab.Handle, struct{}]
+}
+
+// slabSlot is a primary key with its value. A primary key may only have secondary keys but no value.
+type slabSlot[K comparable, V any] struct {
+	primaryKey K
+	value      V
+	hasValue   bool
+}
+
+// NewSlab creates a new SlabMultiKeyMap instance.
+func NewSlab[K comparable, V any](opts ...Option) *SlabMultiKeyMap[K, V] {
+	return &SlabMultiKeyMap[K, V]{
+		handles: make(map[K]slab.Handle),
+		base:    *New[slab.Handle, struct{}](opts...),
+	}
+}
+
+// handle returns the handle of a primary key and allocates one if the primary key does not exist.
+func (m *SlabMultiKeyMap[K, V]) handle(primaryKey K) slab.Handle {
+	h, exists := m.handles[primaryKey]
+	if !exists {
+		h = m.slots.Alloc(slabSlot[K, V]{primaryKey: primaryKey})
+		m.handles[primaryKey] = h
+	}
+	return h
+}
+
+// slot returns the slot of a primary key if it has a value.
+func (m *SlabMultiKeyMap[K, V]) slot(primaryKey K) (*slabSlot[K, V], bool) {
+	h, exists := m.handles[primaryKey]
+	if !exists {
+		return nil, false
+	}
+	slot := m.slots.Get(h)
+	return slot, slot.hasValue
+}
+
+// Put inserts a value with a primary key.
+func (m *SlabMultiKeyMap[K, V]) Put(primaryKey K, value V) {
+	h := m.handle(primaryKey)
+	slot := m.slots.Get(h)
+	slot.value = value
+	if slot.hasValue {
+		return
+	}
+	slot.hasValue = true
+	m.size++
+	m.base.order.PushBack(h)
+}
+
+// PutSecondaryKeys adds secondary keys under a group for a primary key.
+// A secondary key which already points to another primary key is moved to the given primary key.
+func (m *SlabMultiKeyMap[K, V]) PutSecondaryKeys(primaryKey K, group string, keys ...string) {
+	h := m.handle(primaryKey)
+	owners := make([]slab.Handle, 0, len(keys))
+	for _, key := range keys {
+		if owner, exists := m.base.secondary[group][key]; exists && owner != h {
+			owners = append(owners, owner)
+		}
+	}
+	m.base.PutSecondaryKeys(h, group, keys...)
+	for _, owner := range owners {
+		m.release(owner)
+	}
+}
+
+// RemoveSecondaryKeys removes secondary keys from a group if they point to the given primary key.
+// Secondary keys which do not exist or point to another primary key are not changed.
+func (m *SlabMultiKeyMap[K, V]) RemoveSecondaryKeys(primaryKey K, group string, keys ...string) {
+	h, exists := m.handles[primaryKey]
+	if !exists {
+		return
+	}
+	m.base.RemoveSecondaryKeys(h, group, keys...)
+	m.release(h)
+}
+
+// release frees the handle of a primary key which has neither a value nor secondary keys anymore.
+func (m *SlabMultiKeyMap[K, V]) release(h slab.Handle) {
+	slot := m.slots.Get(h)
+	if slot.hasValue || m.base.secondaryTo.has(h) {
+		return
+	}
+	delete(m.handles, slot.primaryKey)
+	m.slots.Free(h)
+}
+
+// HasPrimaryKey checks if a primary key exists.
+func (m *SlabMultiKeyMap[K, V]) HasPrimaryKey(primaryKey K) bool {
+	_, exists := m.slot(primaryKey)
+	return exists
+}
+
+// HasSecondaryKey checks if a secondary key exists in a specific group.
+func (m *SlabMultiKeyMap[K, V]) HasSecondaryKey(group string, key string) bool {
+	return m.base.HasSecondaryKey(group, key)
+}
+
+// GetAllKeyGroups returns all key groups and their secondary keys.
+func (m *SlabMultiKeyMap[K, V]) GetAllKeyGroups() map[string]map[string]K {
+	result := make(map[string]map[string]K, len(m.base.secondary))
+	for group, keys := range m.base.secondary {
+		result[group] = make(map[string]K, len(keys))
+		for key, h := range keys {
+			result[group][key] = m.slots.Get(h).primaryKey
+		}
+	}
+	return result
+}
+
+// Remove removes a primary key and its associated secondary keys.
+func (m *SlabMultiKeyMap[K, V]) Remove(primaryKey K) {
+	h, exists := m.handles[primaryKey]
+	if !exists {
+		return
+	}
+	if m.slots.Get(h).hasValue {
+		m.size--
+	}
+	m.base.Remove(h)
+	delete(m.handles, primaryKey)
+	m.slots.Free(h)
+}
+
+// RekeyPrimary moves the value and all secondary keys of oldPrimaryKey to newPrimaryKey.
+// The entry keeps its position in the insertion order.
+// It returns ErrPrimaryKeyNotFound if oldPrimaryKey does not exist
+// and ErrPrimaryKeyExists if newPrimaryKey already exists.
+func (m *SlabMultiKeyMap[K, V]) RekeyPrimary(oldPrimaryKey K, newPrimaryKey K) error {
+	slot, exists := m.slot(oldPrimaryKey)
+	if !exists {
+		return ErrPrimaryKeyNotFound
+	}
+	if oldPrimaryKey == newPrimaryKey {
+		return nil
+	}
+	h := m.handles[oldPrimaryKey]
+	if newH, exists := m.handles[newPrimaryKey]; exists {
+		if m.slots.Get(newH).hasValue {
+			return ErrPrimaryKeyExists
+		}
+		// newPrimaryKey only has secondary keys, which are merged into the entry.
+		type secondaryKey struct{ group, key string }
+		var keys []secondaryKey
+		for group, key := range m.base.secondaryTo.keys(newH) {
+			keys = append(keys, secondaryKey{group, key})
+		}
+		for _, k := range keys {
+			m.base.PutSecondaryKeys(h, k.group, k.key)
+		}
+		m.release(newH)
+	}
+	delete(m.handles, oldPrimaryKey)
+	m.handles[newPrimaryKey] = h
+	slot.primaryKey = newPrimaryKey
+	return nil
+}
+
+// Get returns a value by primary key.
+func (m *SlabMultiKeyMap[K, V]) Get(primaryKey K) (V, bool) {
+	slot, exists := m.slot(primaryKey)
+	if !exists {
+		var zero V
+		return zero, false
+	}
+	return slot.value, true
+}
+
+// GetBySecondaryKey returns a primary key by secondary key and group.
+// The secondary key points to the handle of the value, so the primary keys are not looked up.
+func (m *SlabMultiKeyMap[K, V]) GetBySecondaryKey(group string, key string) (V, bool) {
+	if m.base.mayContain(group, key) {
+		if h, exists := m.base.secondary[group][key]; exists {
+			slot := m.slots.Get(h)
+			return slot.value, slot.hasValue
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Groups returns the names of all groups which have at least one secondary key, in lexical order.
+func (m *SlabMultiKeyMap[K, V]) Groups() []string {
+	return m.base.Groups()
+}
+
+// GroupSize returns the number of secondary keys in a group.
+func (m *SlabMultiKeyMap[K, V]) GroupSize(group string) int {
+	return m.base.GroupSize(group)
+}
+
+// KeysInGroup returns an iterator over the secondary keys of a group in no particular order.
+// The map must not be modified during the iteration.
+func (m *SlabMultiKeyMap[K, V]) KeysInGroup(group string) iter.Seq[string] {
+	return m.base.KeysInGroup(group)
+}
+
+// SecondaryKeysOf returns the secondary keys of a primary key by group.
+// The keys of each group are in lexical order. Without secondary keys, the map is empty.
+func (m *SlabMultiKeyMap[K, V]) SecondaryKeysOf(primaryKey K) map[string][]string {
+	h, exists := m.handles[primaryKey]
+	if !exists {
+		return make(map[string][]string)
+	}
+	return m.base.SecondaryKeysOf(h)
+}
+
+// GroupBy returns the values of all entries with a secondary key in the group, keyed by that secondary key.
+// Secondary keys whose primary key has no value are left out.
+// A secondary key points to a single primary key, so each slice has exactly one element.
+func (m *SlabMultiKeyMap[K, V]) GroupBy(group string) map[string][]V {
+	result := make(map[string][]V, len(m.base.secondary[group]))
+	for key, h := range m.base.secondary[group] {
+		if slot := m.slots.Get(h); slot.hasValue {
+			result[key] = append(result[key], slot.value)
+		}
+	}
+	return result
+}
+
+// Query returns an iterator over all entries matching the query.
+// The map must not be modified during the iteration.
+func (m *SlabMultiKeyMap[K, V]) Query(q Query) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for h := range m.base.queryKeys(q, maps.Values(m.handles)) {
+			if slot := m.slots.Get(h); slot.hasValue && !yield(slot.primaryKey, slot.value) {
+				return
+			}
+		}
+	}
+}
+
+// Page returns up to limit entries following the cursor in insertion order, and the cursor for the next page.
+func (m *SlabMultiKeyMap[K, V]) Page(cursor Cursor, limit int) ([]Entry[K, V], Cursor, error) {
+	page, next, err := m.base.Page(cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+	entries := make([]Entry[K, V], 0, len(page))
+	for _, entry := range page {
+		slot := m.slots.Get(entry.PrimaryKey)
+		entries = append(entries, Entry[K, V]{PrimaryKey: slot.primaryKey, Value: slot.value})
+	}
+	return entries, next, nil
+}
+
+// GroupPage returns up to limit secondary keys of the group following the cursor together with their entries,
+// ordered by the secondary key, and the cursor for the next page.
+func (m *SlabMultiKeyMap[K, V]) GroupPage(group string, cursor Cursor, limit int) ([]GroupEntry[K, V], Cursor, error) {
+	page, next, err := m.base.groupPage(group, cursor, limit, m.hasValue)
+	if err != nil {
+		return nil, "", err
+	}
+	entries := make([]GroupEntry[K, V], 0, len(page))
+	for _, entry := range page {
+		slot := m.slots.Get(entry.PrimaryKey)
+		entries = append(entries, GroupEntry[K, V]{Key: entry.Key, PrimaryKey: slot.primaryKey, Value: slot.value})
+	}
+	return entries, next, nil
+}
+
+// hasValue is the lookup of the values for the wrapped map, whose primary map is empty.
+func (m *SlabMultiKeyMap[K, V]) hasValue(h slab.Handle) (struct{}, bool) {
+	return struct{}{}, m.slots.Get(h).hasValue
+}
+
+// Keys returns a slice of all primary keys in the map.
+func (m *SlabMultiKeyMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.size)
+	for primaryKey := range m.All() {
+		keys = append(keys, primaryKey)
+	}
+	return keys
+}
+
+// MoveToFront moves an entry to the front of the insertion order.
+// It returns ErrNoInsertionOrder if the map was not created with WithInsertionOrder
+// and ErrPrimaryKeyNotFound if the primary key does not exist.
+func (m *SlabMultiKeyMap[K, V]) MoveToFront(primaryKey K) error {
+	h, exists := m.handles[primaryKey]
+	if !exists && m.base.insertionOrder {
+		return ErrPrimaryKeyNotFound
+	}
+	return m.base.MoveToFront(h)
+}
+
+// MoveToBack moves an entry to the back of the insertion order.
+// It returns ErrNoInsertionOrder if the map was not created with WithInsertionOrder
+// and ErrPrimaryKeyNotFound if the primary key does not exist.
+func (m *SlabMultiKeyMap[K, V]) MoveToBack(primaryKey K) error {
+	h, exists := m.handles[primaryKey]
+	if !exists && m.base.insertionOrder {
+		return ErrPrimaryKeyNotFound
+	}
+	return m.base.MoveToBack(h)
+}
+
+// Has checks if a primary key exists. It is the same as HasPrimaryKey.
+func (m *SlabMultiKeyMap[K, V]) Has(primaryKey K) bool {
+	return m.HasPrimaryKey(primaryKey)
+}
+
+// All returns an iterator over all primary keys and their values.
+// With WithInsertionOrder, the entries are returned in insertion order.
+func (m *SlabMultiKeyMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		handles := maps.Values(m.handles)
+		if m.base.insertionOrder {
+			handles = m.base.order.All()
+		}
+		for h := range handles {
+			if slot := m.slots.Get(h); slot.hasValue && !yield(slot.primaryKey, slot.value) {
+				return
+			}
+		}
+	}
+}
+
+// Size returns the number of elements in the map.
+func (m *SlabMultiKeyMap[K, V]) Size() int {
+	return m.size
+}
+
+// Empty checks if the map is empty.
+func (m *SlabMultiKeyMap[K, V]) Empty() bool {
+	return m.size == 0
+}
+
+// Values returns a slice of all values in the map.
+func (m *SlabMultiKeyMap[K, V]) Values() []V {
+	values := make([]V, 0, m.size)
+	for _, value := range m.All() {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Clear removes all elements from the map.
+func (m *SlabMultiKeyMap[K, V]) Clear() {
+	m.base.Clear()
+	m.handles = make(map[K]slab.Handle)
+	m.slots.Clear()
+	m.size = 0
+}
+
+// String returns a string representation of the map.
+func (m *SlabMultiKeyMap[K, V]) String() string {
+	entries := make([]Entry[K, V], 0, m.size)
+	for primaryKey, value := range m.All() {
+		entries = append(entries, Entry[K, V]{PrimaryKey: primaryKey, Value: value})
+	}
+	return fmt.Sprintf("SlabMultiKeyMap: %v", entries)
+}
@@ -0,0 +1,295 @@
+package multikeymap
+
+import (
+	"fmt"
+	"maps"
+	"runtime"
+	"runtime/metrics"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleNewSlab() {
+	mm := NewSlab[string, int]()
+	mm.Put("Berlin", 3_500_000)
+	mm.PutSecondaryKeys("Berlin", "postcode", "10115", "10117")
+	value, exists := mm.GetBySecondaryKey("postcode", "10117")
+	fmt.Printf("value: %v, exists: %v\n", value, exists)
+
+	// Output:
+	// value: 3500000, exists: true
+}
+
+func TestSlabMultiKeyMap_ImplementsContainerInterface(t *testing.T) {
+	instance := NewSlab[string, int]()
+	if _, ok := any(instance).(container.KeyedContainer[string, int]); !ok {
+		t.Error("SlabMultiKeyMap does not implement the KeyedContainer interface")
+	}
+}
+
+func TestSlabMultiKeyMap(t *testing.T) {
+	mm := NewSlab[string, int]()
+	mm.Put("key1", 1)
+	mm.Put("key2", 2)
+	mm.Put("key1", 10)
+	mm.PutSecondaryKeys("key1", "group1", "secKey1")
+	assert.Equal(t, 2, mm.Size())
+
+	value, exists := mm.Get("key1")
+	assert.True(t, exists)
+	assert.Equal(t, 10, value)
+	value, exists = mm.GetBySecondaryKey("group1", "secKey1")
+	assert.True(t, exists)
+	assert.Equal(t, 10, value)
+	assert.True(t, mm.Has("key2"))
+	assert.False(t, mm.Has("key3"))
+	assert.ElementsMatch(t, []string{"key1", "key2"}, mm.Keys())
+	assert.ElementsMatch(t, []int{10, 2}, mm.Values())
+
+	require.NoError(t, mm.RekeyPrimary("key1", "key3"))
+	require.NoError(t, mm.RekeyPrimary("key3", "key3"))
+	require.ErrorIs(t, mm.RekeyPrimary("key1", "key4"), ErrPrimaryKeyNotFound)
+	require.ErrorIs(t, mm.RekeyPrimary("key3", "key2"), ErrPrimaryKeyExists)
+	assert.Equal(t, map[string]map[string]string{"group1": {"secKey1": "key3"}}, mm.GetAllKeyGroups())
+	assert.Equal(t, map[string][]string{"group1": {"secKey1"}}, mm.SecondaryKeysOf("key3"))
+	assert.Empty(t, mm.SecondaryKeysOf("key1"))
+
+	mm.Remove("key3")
+	assert.False(t, mm.HasSecondaryKey("group1", "secKey1"))
+	assert.Equal(t, 1, mm.Size())
+	assert.Equal(t, 1, mm.slots.Len())
+	mm.Remove("key3")
+
+	mm.Clear()
+	assert.True(t, mm.Empty())
+	assert.Equal(t, 0, mm.slots.Len())
+	assert.Empty(t, mm.handles)
+}
+
+func TestSlabMultiKeyMap_SecondaryKeysWithoutValue(t *testing.T) {
+	mm := NewSlab[string, int]()
+	mm.PutSecondaryKeys("key1", "group1", "secKey1", "secKey2")
+	assert.False(t, mm.HasPrimaryKey("key1"))
+	assert.True(t, mm.HasSecondaryKey("group1", "secKey1"))
+	_, exists := mm.GetBySecondaryKey("group1", "secKey1")
+	assert.False(t, exists)
+	assert.Empty(t, mm.GroupBy("group1"))
+
+	// Rekeying onto key1 merges its secondary keys into the entry.
+	mm.Put("key2", 2)
+	mm.PutSecondaryKeys("key2", "group1", "secKey3")
+	require.NoError(t, mm.RekeyPrimary("key2", "key1"))
+	assert.Equal(t, map[string][]string{"group1": {"secKey1", "secKey2", "secKey3"}}, mm.SecondaryKeysOf("key1"))
+	assert.Equal(t, map[string][]int{"secKey1": {2}, "secKey2": {2}, "secKey3": {2}}, mm.GroupBy("group1"))
+	assert.Equal(t, 1, mm.slots.Len())
+
+	// key3 has neither a value nor secondary keys anymore, so its handle is freed.
+	mm.PutSecondaryKeys("key3", "group2", "secKey4")
+	mm.PutSecondaryKeys("key1", "group2", "secKey4")
+	assert.Equal(t, 1, mm.slots.Len())
+	assert.Equal(t, []string{"key1"}, mm.Keys())
+
+	// key5 has no value, so removing its only secondary key frees its handle.
+	mm.PutSecondaryKeys("key5", "group2", "secKey5")
+	assert.Equal(t, 2, mm.slots.Len())
+	mm.RemoveSecondaryKeys("key5", "group2", "secKey5")
+	assert.Equal(t, 1, mm.slots.Len())
+	assert.False(t, mm.HasSecondaryKey("group2", "secKey5"))
+}
+
+func TestSlabMultiKeyMap_Groups(t *testing.T) {
+	for name, opts := range map[string][]Option{"default": nil, "interned": {WithInterning()}} {
+		t.Run(name, func(t *testing.T) {
+			mm := NewSlab[string, int](opts...)
+			mm.Put("key1", 1)
+			mm.Put("key2", 2)
+			mm.PutSecondaryKeys("key1", "group1", "secKey2", "secKey1")
+			mm.PutSecondaryKeys("key1", "group2", "secKey3")
+			mm.PutSecondaryKeys("key2", "group1", "secKey4")
+			// key3 has secondary keys, but no value.
+			mm.PutSecondaryKeys("key3", "group2", "secKey5")
+
+			assert.Equal(t, []string{"group1", "group2"}, mm.Groups())
+			assert.Equal(t, 3, mm.GroupSize("group1"))
+			assert.ElementsMatch(t, []string{"secKey1", "secKey2", "secKey4"}, slices.Collect(mm.KeysInGroup("group1")))
+			assert.Equal(t, map[string][]string{"group1": {"secKey1", "secKey2"}, "group2": {"secKey3"}}, mm.SecondaryKeysOf("key1"))
+			assert.Equal(t, map[string][]string{"group2": {"secKey5"}}, mm.SecondaryKeysOf("key3"))
+			assert.Equal(t, map[string][]int{"secKey1": {1}, "secKey2": {1}, "secKey4": {2}}, mm.GroupBy("group1"))
+			assert.Equal(t, map[string][]int{"secKey3": {1}}, mm.GroupBy("group2"))
+
+			// The entries without a value are neither counted nor iterated.
+			assert.Equal(t, 2, mm.Size())
+			assert.ElementsMatch(t, []string{"key1", "key2"}, mm.Keys())
+			assert.Equal(t, map[string]int{"key2": 2}, maps.Collect(mm.Query(Not(In("group2", "secKey3", "secKey5")))))
+			groupEntries, _, err := mm.GroupPage("group2", "", 2)
+			require.NoError(t, err)
+			assert.Equal(t, []GroupEntry[string, int]{{"secKey3", "key1", 1}}, groupEntries)
+
+			mm.Remove("key1")
+			assert.Equal(t, 1, mm.GroupSize("group1"))
+			mm.Remove("key3")
+			assert.Equal(t, []string{"group1"}, mm.Groups())
+			assert.Equal(t, 1, mm.Size())
+		})
+	}
+}
+
+func TestSlabMultiKeyMap_QueryEstimate(t *testing.T) {
+	mm := NewSlab[string, int]()
+	for n := range 4 {
+		key := fmt.Sprintf("key%d", n)
+		mm.Put(key, n)
+		mm.PutSecondaryKeys(key, "group1", fmt.Sprintf("secKey%d", n))
+	}
+	mm.PutSecondaryKeys("key4", "group1", "secKey4")
+	assert.Equal(t, 4, mm.base.queryEstimate(Not(Eq("group1", "secKey0"))))
+	assert.Equal(t, 1, mm.base.queryEstimate(And(Not(Eq("group1", "secKey0")), Eq("group1", "secKey1"))))
+	sorted := mm.base.sortBySelectivity([]Query{In("group1", "secKey0", "secKey1", "secKey2"), Eq("group1", "secKey3")})
+	assert.Equal(t, Eq("group1", "secKey3"), sorted[0])
+}
+
+func TestSlabMultiKeyMap_QueryAndPage(t *testing.T) {
+	mm := NewSlab[string, int](WithInsertionOrder())
+	for n := range 3 {
+		key := fmt.Sprintf("key%d", n)
+		mm.Put(key, n)
+		mm.PutSecondaryKeys(key, "group1", fmt.Sprintf("secKey%d", n))
+	}
+	require.NoError(t, mm.MoveToBack("key0"))
+	require.ErrorIs(t, mm.MoveToFront("key3"), ErrPrimaryKeyNotFound)
+	assert.Equal(t, []string{"key1", "key2", "key0"}, mm.Keys())
+	assert.Equal(t, "SlabMultiKeyMap: [{key1 1} {key2 2} {key0 0}]", mm.String())
+
+	for primaryKey, value := range mm.Query(Eq("group1", "secKey1")) {
+		assert.Equal(t, "key1", primaryKey)
+		assert.Equal(t, 1, value)
+	}
+	entries, cursor, err := mm.Page("", 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry[string, int]{{"key1", 1}, {"key2", 2}}, entries)
+	entries, _, err = mm.Page(cursor, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Entry[string, int]{{"key0", 0}}, entries)
+	_, _, err = mm.Page("", 0)
+	require.ErrorIs(t, err, ErrInvalidLimit)
+
+	groupEntries, _, err := mm.GroupPage("group1", "", 1)
+	require.NoError(t, err)
+	assert.Equal(t, []GroupEntry[string, int]{{"secKey0", "key0", 0}}, groupEntries)
+	_, _, err = mm.GroupPage("group1", "", 0)
+	require.ErrorIs(t, err, ErrInvalidLimit)
+	assert.Equal(t, []string{"group1"}, mm.Groups())
+	assert.Equal(t, 3, mm.GroupSize("group1"))
+	assert.Len(t, slices.Collect(mm.KeysInGroup("group1")), 3)
+
+	require.ErrorIs(t, NewSlab[string, int]().MoveToBack("key0"), ErrNoInsertionOrder)
+}
+
+func BenchmarkSlabMultiKeyMapGet(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size_%d", v.size), func(b *testing.B) {
+			m := NewSlab[string, int]()
+			for n := range v.size {
+				m.Put(strconv.Itoa(n), n)
+			}
+			b.ResetTimer()
+			for range b.N {
+				for n := range v.size {
+					m.Get(strconv.Itoa(n))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSlabMultiKeyMapPut(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size_%d", v.size), func(b *testing.B) {
+			m := NewSlab[string, int]()
+			b.ResetTimer()
+			for range b.N {
+				for n := range v.size {
+					m.Put(strconv.Itoa(n), n)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSlabMultiKeyMapRemove(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size_%d", v.size), func(b *testing.B) {
+			m := NewSlab[string, int]()
+			for n := range v.size {
+				m.Put(strconv.Itoa(n), n)
+			}
+			b.ResetTimer()
+			for range b.N {
+				for n := range v.size {
+					m.Remove(strconv.Itoa(n))
+				}
+			}
+		})
+	}
+}
+
+// slabBenchmarkValue is a value with pointers, which the garbage collector has to follow.
+type slabBenchmarkValue struct {
+	Name    string
+	Aliases []string
+}
+
+// slabBenchmarkModes compares MultiKeyMap and SlabMultiKeyMap with pointer values.
+var slabBenchmarkModes = []struct {
+	name string
+	new  func() Interface[string, *slabBenchmarkValue]
+}{
+	{name: "default", new: func() Interface[string, *slabBenchmarkValue] { return New[string, *slabBenchmarkValue]() }},
+	{name: "slab", new: func() Interface[string, *slabBenchmarkValue] { return NewSlab[string, *slabBenchmarkValue]() }},
+}
+
+// putSlabBenchmarkEntries puts size primary keys with pointer values and two secondary keys in each of two groups.
+func putSlabBenchmarkEntries(m Interface[string, *slabBenchmarkValue], size int) {
+	for n := range size {
+		primaryKey := strconv.Itoa(n)
+		m.Put(primaryKey, &slabBenchmarkValue{Name: primaryKey, Aliases: []string{"a" + primaryKey, "b" + primaryKey}})
+		m.PutSecondaryKeys(primaryKey, "postcode", "p"+primaryKey, "q"+primaryKey)
+		m.PutSecondaryKeys(primaryKey, "alias", "a"+primaryKey, "b"+primaryKey)
+	}
+}
+
+// BenchmarkSlabMultiKeyMapGC measures a full garbage collection while a map with pointer values is alive.
+// Besides the time per collection, it reports the scannable heap and the stop-the-world pauses per collection.
+func BenchmarkSlabMultiKeyMapGC(b *testing.B) {
+	samples := []metrics.Sample{{Name: "/gc/scan/heap:bytes"}}
+	for _, mode := range slabBenchmarkModes {
+		for _, v := range benchmarkSizes {
+			b.Run(fmt.Sprintf("%s/size_%d", mode.name, v.size), func(b *testing.B) {
+				var before, after runtime.MemStats
+				runtime.GC()
+				metrics.Read(samples)
+				scanBefore := samples[0].Value.Uint64()
+				m := mode.new()
+				putSlabBenchmarkEntries(m, v.size)
+				runtime.GC()
+				metrics.Read(samples)
+				scanAfter := samples[0].Value.Uint64()
+				runtime.ReadMemStats(&before)
+				b.ResetTimer()
+				for range b.N {
+					runtime.GC()
+				}
+				b.StopTimer()
+				runtime.ReadMemStats(&after)
+				b.ReportMetric(float64(scanAfter-scanBefore)/float64(v.size), "scan-B/entry")
+				b.ReportMetric(float64(after.PauseTotalNs-before.PauseTotalNs)/float64(b.N), "pause-ns/op")
+				runtime.KeepAlive(m)
+			})
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package trikeymap
+
+import (
+	"fmt"
+	"iter"
+	"sync"
+)
+
+// ConcurrentTriKeyMap is the same as TriKeyMap, but it is safe for concurrent use.
+// It uses a RWMutex to protect the map from concurrent reads and writes.
+// Therefore, it is slower than TriKeyMap, but it is safe for concurrent use.
+// The wrapped TriKeyMap is not exposed, so it can only be accessed while holding the lock.
+type ConcurrentTriKeyMap[KeyA comparable, KeyB comparable, KeyC comparable, V any] struct {
+	mu   sync.RWMutex
+	base TriKeyMap[KeyA, KeyB, KeyC, V]
+}
+
+// NewConcurrent creates a new instance of ConcurrentTriKeyMap.
+func NewConcurrent[KeyA comparable, KeyB comparable, KeyC comparable, V any]() *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V] {
+	return &ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]{
+		base: *New[KeyA, KeyB, KeyC, V](),
+	}
+}
+
+// Put stores a value with three keys. It only fails if one of the keys is already set with different other keys.
+// The returned error is a *ConflictError.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) Put(keyA KeyA, keyB KeyB, keyC KeyC, value V) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.base.Put(keyA, keyB, keyC, value)
+}
+
+// GetByKeyA retrieves a value using the first key.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) GetByKeyA(keyA KeyA) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.GetByKeyA(keyA)
+}
+
+// GetByKeyB retrieves a value using the second key.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) GetByKeyB(keyB KeyB) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.GetByKeyB(keyB)
+}
+
+// GetByKeyC retrieves a value using the third key.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) GetByKeyC(keyC KeyC) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.GetByKeyC(keyC)
+}
+
+// RemoveByKeyA removes a value using the first key, ensuring the corresponding other keys are also deleted.
+// It returns ErrKeyANotFound if keyA does not exist.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) RemoveByKeyA(keyA KeyA) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.base.RemoveByKeyA(keyA)
+}
+
+// RemoveByKeyB removes a value using the second key, ensuring the corresponding other keys are also deleted.
+// It returns ErrKeyBNotFound if keyB does not exist.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) RemoveByKeyB(keyB KeyB) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.base.RemoveByKeyB(keyB)
+}
+
+// RemoveByKeyC removes a value using the third key, ensuring the corresponding other keys are also deleted.
+// It returns ErrKeyCNotFound if keyC does not exist.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) RemoveByKeyC(keyC KeyC) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.base.RemoveByKeyC(keyC)
+}
+
+// Keys returns a slice of all first keys in the map.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) Keys() []KeyA {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.base.Keys()
+}
+
+// Has checks if the first key exists.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) Has(keyA KeyA) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.base.Has(keyA)
+}
+
+// Get retrieves a value using the first key. It is the same as GetByKeyA.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) Get(keyA KeyA) (V, bool) {
+	return m.GetByKeyA(keyA)
+}
+
+// All returns an iterator over all first keys and their values.
+// The entries are collected under a read lock when the iteration starts,
+// so the map may be modified while iterating.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) All() iter.Seq2[KeyA, V] {
+	return func(yield func(KeyA, V) bool) {
+		m.mu.RLock()
+		keys := m.base.Keys()
+		values := make([]V, 0, len(keys))
+		for _, keyA := range keys {
+			values = append(values, m.base.dataByKeyA[keyA])
+		}
+		m.mu.RUnlock()
+		for i, keyA := range keys {
+			if !yield(keyA, values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Empty checks if the map is empty.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) Empty() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.Empty()
+}
+
+// Size returns the number of elements in the map.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) Size() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.Size()
+}
+
+// Values returns a slice of all values in the map.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) Values() []V {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.base.Values()
+}
+
+// Clear removes all elements from the map.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.base.Clear()
+}
+
+// String returns a string representation of the map.
+func (m *ConcurrentTriKeyMap[KeyA, KeyB, KeyC, V]) String() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return fmt.Sprintf("ConcurrentTriKeyMap: %v", m.base.dataByKeyA)
+}
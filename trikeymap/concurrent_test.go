@@ -0,0 +1,164 @@
+package trikeymap
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleNewConcurrent() {
+	tm := NewConcurrent[int, string, string, string]()
+	_ = tm.Put(1, "ext-1", "berlin", "Berlin")
+	value, exists := tm.GetByKeyC("berlin")
+	fmt.Printf("[Key C] value: %v, exists: %v\n", value, exists)
+	// Output:
+	// [Key C] value: Berlin, exists: true
+}
+
+func TestConcurrentTriKeyMap_ImplementsContainerInterface(t *testing.T) {
+	instance := NewConcurrent[int, int, int, int]()
+	if _, ok := any(instance).(container.Container[int]); !ok {
+		t.Error("ConcurrentTriKeyMap does not implement the Container interface")
+	}
+}
+
+func TestConcurrentTriKeyMap_KeyedContainer(t *testing.T) {
+	tm := NewConcurrent[string, int, string, string]()
+	require.NoError(t, tm.Put("keyA1", 1, "keyC1", "value1"))
+	require.NoError(t, tm.Put("keyA2", 2, "keyC2", "value2"))
+
+	var keyed container.KeyedContainer[string, string] = tm
+	assert.ElementsMatch(t, []string{"keyA1", "keyA2"}, keyed.Keys())
+	assert.True(t, keyed.Has("keyA1"))
+	assert.False(t, keyed.Has("keyA3"))
+	value, exists := keyed.Get("keyA2")
+	assert.True(t, exists)
+	assert.Equal(t, "value2", value)
+	assert.Equal(t, map[string]string{"keyA1": "value1", "keyA2": "value2"}, container.Collect(keyed))
+}
+
+func TestConcurrentTriKeyMap_HidesUnderlyingMap(t *testing.T) {
+	concurrentType := reflect.TypeFor[ConcurrentTriKeyMap[string, int, string, string]]()
+	for i := range concurrentType.NumField() {
+		field := concurrentType.Field(i)
+		assert.False(t, field.IsExported(), "field %s allows access without locking", field.Name)
+	}
+
+	// Every method of TriKeyMap must be implemented with locking, as nothing is promoted.
+	pointerType := reflect.PointerTo(concurrentType)
+	baseType := reflect.TypeFor[*TriKeyMap[string, int, string, string]]()
+	for i := range baseType.NumMethod() {
+		method := baseType.Method(i)
+		_, exists := pointerType.MethodByName(method.Name)
+		assert.True(t, exists, "method %s is missing", method.Name)
+	}
+}
+
+func TestConcurrentTriKeyMap_SetAndRemove(t *testing.T) {
+	tm := NewConcurrent[string, int, string, string]()
+	require.NoError(t, tm.Put("keyA1", 1, "keyC1", "value1"))
+	require.NoError(t, tm.Put("keyA2", 2, "keyC2", "value2"))
+	require.NoError(t, tm.Put("keyA3", 3, "keyC3", "value3"))
+	require.Error(t, tm.Put("keyA4", 1, "keyC4", "value4"))
+
+	value, exists := tm.GetByKeyB(2)
+	assert.True(t, exists)
+	assert.Equal(t, "value2", value)
+
+	require.NoError(t, tm.RemoveByKeyA("keyA1"))
+	require.NoError(t, tm.RemoveByKeyB(2))
+	require.NoError(t, tm.RemoveByKeyC("keyC3"))
+	require.Error(t, tm.RemoveByKeyC("keyC3"))
+	assert.True(t, tm.Empty())
+}
+
+func TestConcurrentTriKeyMap_String(t *testing.T) {
+	tm := NewConcurrent[string, int, string, string]()
+	require.NoError(t, tm.Put("keyA1", 1, "keyC1", "value1"))
+	assert.Equal(t, "ConcurrentTriKeyMap: map[keyA1:value1]", tm.String())
+}
+
+func TestConcurrentTriKeyMap_ClearAndValues(t *testing.T) {
+	tm := NewConcurrent[string, int, string, string]()
+	require.NoError(t, tm.Put("keyA1", 1, "keyC1", "value1"))
+	require.NoError(t, tm.Put("keyA2", 2, "keyC2", "value2"))
+	assert.ElementsMatch(t, []string{"value1", "value2"}, tm.Values())
+	assert.Equal(t, 2, tm.Size())
+
+	tm.Clear()
+	assert.True(t, tm.Empty())
+}
+
+func TestConcurrentTriKeyMap_ConcurrentAccess(t *testing.T) {
+	tm := NewConcurrent[string, int, string, string]()
+	var wg sync.WaitGroup
+	const numGoroutines = 100
+
+	for i := range numGoroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := tm.Put(fmt.Sprintf("keyA%d", i), i, fmt.Sprintf("keyC%d", i), fmt.Sprintf("value%d", i)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			tm.Size()
+		}()
+	}
+	wg.Wait()
+
+	for i := range numGoroutines {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if value, exists := tm.GetByKeyC(fmt.Sprintf("keyC%d", i)); !exists || value != fmt.Sprintf("value%d", i) {
+				t.Errorf("expected value%d, got %v", i, value)
+			}
+			if err := tm.RemoveByKeyB(i); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			tm.Empty()
+		}()
+	}
+	wg.Wait()
+	assert.True(t, tm.Empty())
+}
+
+// Benchmarks
+
+func BenchmarkConcurrentTriKeyMapGet(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size_%d", v.size), func(b *testing.B) {
+			m := NewConcurrent[string, int, string, string]()
+			for n := range v.size {
+				_ = m.Put(strconv.Itoa(n), n, strconv.Itoa(n), strconv.Itoa(n))
+			}
+			b.ResetTimer()
+			for range b.N {
+				for n := range v.size {
+					m.GetByKeyA(strconv.Itoa(n))
+					m.GetByKeyB(n)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkConcurrentTriKeyMapPut(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size_%d", v.size), func(b *testing.B) {
+			m := NewConcurrent[string, int, string, string]()
+			b.ResetTimer()
+			for range b.N {
+				for n := range v.size {
+					_ = m.Put(strconv.Itoa(n), n, strconv.Itoa(n), strconv.Itoa(n))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package trikeymap
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrKeyANotFound is returned if keyA does not exist.
+	ErrKeyANotFound = errors.New("keyA does not exist")
+	// ErrKeyBNotFound is returned if keyB does not exist.
+	ErrKeyBNotFound = errors.New("keyB does not exist")
+	// ErrKeyCNotFound is returned if keyC does not exist.
+	ErrKeyCNotFound = errors.New("keyC does not exist")
+	// ErrConflict is matched by every *ConflictError.
+	ErrConflict = errors.New("key conflict")
+	// ErrKeyAConflict is matched by a *ConflictError if keyA is already set with a different keyB or keyC.
+	ErrKeyAConflict = fmt.Errorf("%w: keyA is already set with different keys", ErrConflict)
+	// ErrKeyBConflict is matched by a *ConflictError if keyB is already set with a different keyA.
+	ErrKeyBConflict = fmt.Errorf("%w: keyB is already set with a different keyA", ErrConflict)
+	// ErrKeyCConflict is matched by a *ConflictError if keyC is already set with a different keyA.
+	ErrKeyCConflict = fmt.Errorf("%w: keyC is already set with a different keyA", ErrConflict)
+)
+
+// ConflictError is returned if a key is already set with different keys.
+// It matches ErrConflict and one of ErrKeyAConflict, ErrKeyBConflict or ErrKeyCConflict with errors.Is.
+type ConflictError[KeyA comparable, KeyB comparable, KeyC comparable] struct {
+	// Err is one of ErrKeyAConflict, ErrKeyBConflict or ErrKeyCConflict.
+	Err error
+	// KeyA, KeyB and KeyC are the keys which should have been stored together.
+	KeyA KeyA
+	KeyB KeyB
+	KeyC KeyC
+	// ExistingKeyA is the keyA which KeyB or KeyC is already set with,
+	// if Err is ErrKeyBConflict or ErrKeyCConflict.
+	ExistingKeyA KeyA
+	// ExistingKeyB and ExistingKeyC are the keys which KeyA is already set with, if Err is ErrKeyAConflict.
+	ExistingKeyB KeyB
+	ExistingKeyC KeyC
+}
+
+// Error returns the error message.
+func (e *ConflictError[KeyA, KeyB, KeyC]) Error() string {
+	switch {
+	case errors.Is(e.Err, ErrKeyAConflict):
+		return fmt.Sprintf("keyA %v is already set with different keys %v and %v", e.KeyA, e.ExistingKeyB, e.ExistingKeyC)
+	case errors.Is(e.Err, ErrKeyBConflict):
+		return fmt.Sprintf("keyB %v is already set with a different keyA %v", e.KeyB, e.ExistingKeyA)
+	}
+	return fmt.Sprintf("keyC %v is already set with a different keyA %v", e.KeyC, e.ExistingKeyA)
+}
+
+// Unwrap returns the underlying sentinel error.
+func (e *ConflictError[KeyA, KeyB, KeyC]) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,186 @@
+package trikeymap
+
+import (
+	"fmt"
+	"iter"
+)
+
+// TriKeyMap is a generic in-memory map with three independent keys for each value.
+// Every key is unique, like the keys of a BiKeyMap.
+// It implements container/Container.
+type TriKeyMap[KeyA comparable, KeyB comparable, KeyC comparable, V any] struct {
+	dataByKeyA map[KeyA]V
+	keyAByKeyB map[KeyB]KeyA
+	keyAByKeyC map[KeyC]KeyA
+	keyBByKeyA map[KeyA]KeyB
+	keyCByKeyA map[KeyA]KeyC
+}
+
+// New creates a new instance of TriKeyMap.
+func New[KeyA comparable, KeyB comparable, KeyC comparable, V any]() *TriKeyMap[KeyA, KeyB, KeyC, V] {
+	return &TriKeyMap[KeyA, KeyB, KeyC, V]{
+		dataByKeyA: make(map[KeyA]V),
+		keyAByKeyB: make(map[KeyB]KeyA),
+		keyAByKeyC: make(map[KeyC]KeyA),
+		keyBByKeyA: make(map[KeyA]KeyB),
+		keyCByKeyA: make(map[KeyA]KeyC),
+	}
+}
+
+// Put stores a value with three keys. It only fails if one of the keys is already set with different other keys.
+// The returned error is a *ConflictError.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) Put(keyA KeyA, keyB KeyB, keyC KeyC, value V) error {
+	// Check if one key is set without the others, or if they do not point to each other.
+	if existingKeyA, keyBExists := m.keyAByKeyB[keyB]; keyBExists && existingKeyA != keyA {
+		return &ConflictError[KeyA, KeyB, KeyC]{Err: ErrKeyBConflict, KeyA: keyA, KeyB: keyB, KeyC: keyC, ExistingKeyA: existingKeyA}
+	}
+	if existingKeyA, keyCExists := m.keyAByKeyC[keyC]; keyCExists && existingKeyA != keyA {
+		return &ConflictError[KeyA, KeyB, KeyC]{Err: ErrKeyCConflict, KeyA: keyA, KeyB: keyB, KeyC: keyC, ExistingKeyA: existingKeyA}
+	}
+	existingKeyB, keyAExists := m.keyBByKeyA[keyA]
+	existingKeyC := m.keyCByKeyA[keyA]
+	if keyAExists && (existingKeyB != keyB || existingKeyC != keyC) {
+		return &ConflictError[KeyA, KeyB, KeyC]{
+			Err: ErrKeyAConflict, KeyA: keyA, KeyB: keyB, KeyC: keyC, ExistingKeyB: existingKeyB, ExistingKeyC: existingKeyC,
+		}
+	}
+
+	// Put the new values for all keys.
+	m.dataByKeyA[keyA] = value
+	m.keyAByKeyB[keyB] = keyA
+	m.keyAByKeyC[keyC] = keyA
+	m.keyBByKeyA[keyA] = keyB
+	m.keyCByKeyA[keyA] = keyC
+	return nil
+}
+
+// GetByKeyA retrieves a value using the first key.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) GetByKeyA(keyA KeyA) (V, bool) {
+	value, exists := m.dataByKeyA[keyA]
+	return value, exists
+}
+
+// GetByKeyB retrieves a value using the second key.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) GetByKeyB(keyB KeyB) (V, bool) {
+	keyA, exists := m.keyAByKeyB[keyB]
+	if !exists {
+		var zero V
+		return zero, false
+	}
+	return m.GetByKeyA(keyA)
+}
+
+// GetByKeyC retrieves a value using the third key.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) GetByKeyC(keyC KeyC) (V, bool) {
+	keyA, exists := m.keyAByKeyC[keyC]
+	if !exists {
+		var zero V
+		return zero, false
+	}
+	return m.GetByKeyA(keyA)
+}
+
+// RemoveByKeyA removes a value using the first key, ensuring the corresponding other keys are also deleted.
+// It returns ErrKeyANotFound if keyA does not exist.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) RemoveByKeyA(keyA KeyA) error {
+	if _, ok := m.dataByKeyA[keyA]; !ok {
+		return ErrKeyANotFound
+	}
+	m.remove(keyA)
+	return nil
+}
+
+// RemoveByKeyB removes a value using the second key, ensuring the corresponding other keys are also deleted.
+// It returns ErrKeyBNotFound if keyB does not exist.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) RemoveByKeyB(keyB KeyB) error {
+	keyA, ok := m.keyAByKeyB[keyB]
+	if !ok {
+		return ErrKeyBNotFound
+	}
+	m.remove(keyA)
+	return nil
+}
+
+// RemoveByKeyC removes a value using the third key, ensuring the corresponding other keys are also deleted.
+// It returns ErrKeyCNotFound if keyC does not exist.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) RemoveByKeyC(keyC KeyC) error {
+	keyA, ok := m.keyAByKeyC[keyC]
+	if !ok {
+		return ErrKeyCNotFound
+	}
+	m.remove(keyA)
+	return nil
+}
+
+// remove removes keyA, the keys paired with it, and the associated value.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) remove(keyA KeyA) {
+	delete(m.keyAByKeyB, m.keyBByKeyA[keyA])
+	delete(m.keyAByKeyC, m.keyCByKeyA[keyA])
+	delete(m.dataByKeyA, keyA)
+	delete(m.keyBByKeyA, keyA)
+	delete(m.keyCByKeyA, keyA)
+}
+
+// Keys returns a slice of all first keys in the map.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) Keys() []KeyA {
+	keys := make([]KeyA, 0, len(m.dataByKeyA))
+	for keyA := range m.dataByKeyA {
+		keys = append(keys, keyA)
+	}
+	return keys
+}
+
+// Has checks if the first key exists.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) Has(keyA KeyA) bool {
+	_, exists := m.dataByKeyA[keyA]
+	return exists
+}
+
+// Get retrieves a value using the first key. It is the same as GetByKeyA.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) Get(keyA KeyA) (V, bool) {
+	return m.GetByKeyA(keyA)
+}
+
+// All returns an iterator over all first keys and their values.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) All() iter.Seq2[KeyA, V] {
+	return func(yield func(KeyA, V) bool) {
+		for keyA, value := range m.dataByKeyA {
+			if !yield(keyA, value) {
+				return
+			}
+		}
+	}
+}
+
+// Empty checks if the map is empty.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) Empty() bool {
+	return len(m.dataByKeyA) == 0
+}
+
+// Size returns the number of elements in the map.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) Size() int {
+	return len(m.dataByKeyA)
+}
+
+// Values returns a slice of all values in the map.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) Values() []V {
+	values := make([]V, 0, len(m.dataByKeyA))
+	for _, value := range m.dataByKeyA {
+		values = append(values, value)
+	}
+	return values
+}
+
+// Clear removes all elements from the map.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) Clear() {
+	m.dataByKeyA = make(map[KeyA]V)
+	m.keyAByKeyB = make(map[KeyB]KeyA)
+	m.keyAByKeyC = make(map[KeyC]KeyA)
+	m.keyBByKeyA = make(map[KeyA]KeyB)
+	m.keyCByKeyA = make(map[KeyA]KeyC)
+}
+
+// String returns a string representation of the map.
+func (m *TriKeyMap[KeyA, KeyB, KeyC, V]) String() string {
+	return fmt.Sprintf("TriKeyMap: %v", m.dataByKeyA)
+}
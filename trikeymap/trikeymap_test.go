@@ -0,0 +1,234 @@
+package trikeymap
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/aeimer/go-multikeymap/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ExampleNew() {
+	tm := New[int, string, string, string]()
+	_ = tm.Put(1, "ext-1", "berlin", "Berlin")
+	value, exists := tm.GetByKeyA(1)
+	fmt.Printf("[Key A] value: %v, exists: %v\n", value, exists)
+	value, exists = tm.GetByKeyB("ext-1")
+	fmt.Printf("[Key B] value: %v, exists: %v\n", value, exists)
+	value, exists = tm.GetByKeyC("berlin")
+	fmt.Printf("[Key C] value: %v, exists: %v\n", value, exists)
+	// Output:
+	// [Key A] value: Berlin, exists: true
+	// [Key B] value: Berlin, exists: true
+	// [Key C] value: Berlin, exists: true
+}
+
+func TestTriKeyMap_ImplementsContainerInterface(t *testing.T) {
+	instance := New[int, int, int, int]()
+	if _, ok := any(instance).(container.Container[int]); !ok {
+		t.Error("TriKeyMap does not implement the Container interface")
+	}
+}
+
+func TestTriKeyMap_KeyedContainer(t *testing.T) {
+	tm := New[string, int, string, string]()
+	require.NoError(t, tm.Put("keyA1", 1, "keyC1", "value1"))
+	require.NoError(t, tm.Put("keyA2", 2, "keyC2", "value2"))
+
+	var keyed container.KeyedContainer[string, string] = tm
+	assert.ElementsMatch(t, []string{"keyA1", "keyA2"}, keyed.Keys())
+	assert.True(t, keyed.Has("keyA1"))
+	assert.False(t, keyed.Has("keyA3"))
+	value, exists := keyed.Get("keyA2")
+	assert.True(t, exists)
+	assert.Equal(t, "value2", value)
+	assert.Equal(t, map[string]string{"keyA1": "value1", "keyA2": "value2"}, container.Collect(keyed))
+}
+
+func TestTriKeyMap_SetAndGet(t *testing.T) {
+	tm := New[string, int, string, string]()
+
+	err := tm.Put("keyA1", 1, "keyC1", "value1")
+	require.NoError(t, err)
+	err = tm.Put("keyA1", 1, "keyC1", "value2")
+	require.NoError(t, err)
+
+	for _, get := range []func() (string, bool){
+		func() (string, bool) { return tm.GetByKeyA("keyA1") },
+		func() (string, bool) { return tm.GetByKeyB(1) },
+		func() (string, bool) { return tm.GetByKeyC("keyC1") },
+	} {
+		value, exists := get()
+		assert.True(t, exists)
+		assert.Equal(t, "value2", value)
+	}
+}
+
+func TestTriKeyMap_SetDuplicateKeys(t *testing.T) {
+	tm := New[string, int, string, string]()
+
+	err := tm.Put("keyA1", 1, "keyC1", "value1")
+	require.NoError(t, err)
+
+	err = tm.Put("keyA2", 1, "keyC2", "value2")
+	require.ErrorIs(t, err, ErrKeyBConflict)
+	require.EqualError(t, err, "keyB 1 is already set with a different keyA keyA1")
+	err = tm.Put("keyA2", 2, "keyC1", "value2")
+	require.ErrorIs(t, err, ErrKeyCConflict)
+	require.EqualError(t, err, "keyC keyC1 is already set with a different keyA keyA1")
+	err = tm.Put("keyA1", 2, "keyC1", "value2")
+	require.ErrorIs(t, err, ErrKeyAConflict)
+	require.EqualError(t, err, "keyA keyA1 is already set with different keys 1 and keyC1")
+	err = tm.Put("keyA1", 1, "keyC2", "value2")
+	require.ErrorIs(t, err, ErrKeyAConflict)
+	require.ErrorIs(t, err, ErrConflict)
+
+	var conflictErr *ConflictError[string, int, string]
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, "keyC2", conflictErr.KeyC)
+	assert.Equal(t, 1, conflictErr.ExistingKeyB)
+	assert.Equal(t, "keyC1", conflictErr.ExistingKeyC)
+
+	// The failed puts must not change the map.
+	assert.Equal(t, 1, tm.Size())
+	_, exists := tm.GetByKeyA("keyA2")
+	assert.False(t, exists)
+}
+
+func TestTriKeyMap_Remove(t *testing.T) {
+	tests := []struct {
+		name   string
+		remove func(tm *TriKeyMap[string, int, string, string]) error
+	}{
+		{name: "RemoveByKeyA", remove: func(tm *TriKeyMap[string, int, string, string]) error { return tm.RemoveByKeyA("keyA1") }},
+		{name: "RemoveByKeyB", remove: func(tm *TriKeyMap[string, int, string, string]) error { return tm.RemoveByKeyB(1) }},
+		{name: "RemoveByKeyC", remove: func(tm *TriKeyMap[string, int, string, string]) error { return tm.RemoveByKeyC("keyC1") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := New[string, int, string, string]()
+			require.NoError(t, tm.Put("keyA1", 1, "keyC1", "value1"))
+			require.NoError(t, tm.Put("keyA2", 2, "keyC2", "value2"))
+
+			require.NoError(t, tt.remove(tm))
+			_, exists := tm.GetByKeyA("keyA1")
+			assert.False(t, exists)
+			_, exists = tm.GetByKeyB(1)
+			assert.False(t, exists)
+			_, exists = tm.GetByKeyC("keyC1")
+			assert.False(t, exists)
+			assert.Equal(t, 1, tm.Size())
+
+			// All keys are free again.
+			require.NoError(t, tm.Put("keyA3", 1, "keyC1", "value3"))
+		})
+	}
+}
+
+func TestTriKeyMap_Remove_NotFound(t *testing.T) {
+	tm := New[string, int, string, string]()
+	require.ErrorIs(t, tm.RemoveByKeyA("nonExistentKey"), ErrKeyANotFound)
+	require.ErrorIs(t, tm.RemoveByKeyB(999), ErrKeyBNotFound)
+	require.ErrorIs(t, tm.RemoveByKeyC("nonExistentKey"), ErrKeyCNotFound)
+}
+
+func TestTriKeyMap_String(t *testing.T) {
+	tm := New[string, int, string, string]()
+	err := tm.Put("keyA1", 1, "keyC1", "value1")
+	require.NoError(t, err)
+
+	expected := "TriKeyMap: map[keyA1:value1]"
+	assert.Equal(t, expected, tm.String())
+}
+
+func TestTriKeyMap_EmptyAndSize(t *testing.T) {
+	tm := New[string, int, string, string]()
+	assert.True(t, tm.Empty())
+
+	err := tm.Put("keyA1", 1, "keyC1", "value1")
+	require.NoError(t, err)
+	assert.False(t, tm.Empty())
+	assert.Equal(t, 1, tm.Size())
+}
+
+func TestTriKeyMap_Clear(t *testing.T) {
+	tm := New[string, int, string, string]()
+	require.NoError(t, tm.Put("keyA1", 1, "keyC1", "value1"))
+	require.NoError(t, tm.Put("keyA2", 2, "keyC2", "value2"))
+
+	tm.Clear()
+	assert.True(t, tm.Empty())
+	assert.Equal(t, 0, tm.Size())
+	_, exists := tm.GetByKeyC("keyC1")
+	assert.False(t, exists)
+}
+
+func TestTriKeyMap_Values(t *testing.T) {
+	tm := New[string, int, string, string]()
+	require.NoError(t, tm.Put("keyA1", 1, "keyC1", "value1"))
+	require.NoError(t, tm.Put("keyA2", 2, "keyC2", "value2"))
+
+	assert.ElementsMatch(t, []string{"value1", "value2"}, tm.Values())
+}
+
+// Benchmarks
+
+var benchmarkSizes = []struct {
+	size int
+}{
+	{size: 100},
+	{size: 1000},
+	{size: 10_000},
+	{size: 100_000},
+}
+
+func BenchmarkTriKeyMapGet(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size_%d", v.size), func(b *testing.B) {
+			m := New[string, int, string, string]()
+			for n := range v.size {
+				_ = m.Put(strconv.Itoa(n), n, strconv.Itoa(n), strconv.Itoa(n))
+			}
+			b.ResetTimer()
+			for range b.N {
+				for n := range v.size {
+					m.GetByKeyA(strconv.Itoa(n))
+					m.GetByKeyB(n)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkTriKeyMapPut(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size_%d", v.size), func(b *testing.B) {
+			m := New[string, int, string, string]()
+			b.ResetTimer()
+			for range b.N {
+				for n := range v.size {
+					_ = m.Put(strconv.Itoa(n), n, strconv.Itoa(n), strconv.Itoa(n))
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkTriKeyMapRemove(b *testing.B) {
+	for _, v := range benchmarkSizes {
+		b.Run(fmt.Sprintf("size_%d", v.size), func(b *testing.B) {
+			m := New[string, int, string, string]()
+			for n := range v.size {
+				_ = m.Put(strconv.Itoa(n), n, strconv.Itoa(n), strconv.Itoa(n))
+			}
+			b.ResetTimer()
+			for range b.N {
+				for n := range v.size {
+					_ = m.RemoveByKeyB(n)
+				}
+			}
+		})
+	}
+}